@@ -0,0 +1,181 @@
+package memlog
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicMemLog is a bounded ring buffer that supports
+// appending from many goroutines without acquiring a lock,
+// for latency sensitive code paths.
+//
+// Append claims a slot by advancing the tail with a
+// compare-and-swap loop and publishes the entry with another
+// compare-and-swap, so producers never wait for readers.  The
+// methods that read the log share a lock with each other, but
+// not with Append.  Entries are published as immutable values so
+// readers never observe a partially written entry; entries that
+// are still being appended when the log is read are skipped by
+// Slice and returned by a later Drain.
+//
+// AtomicMemLog is thread-safe
+type AtomicMemLog[T any] struct {
+	slots []atomic.Pointer[sequenced[T]]
+
+	// head is the sequence number of the oldest entry that
+	// has not been overwritten or cleared, and tail is the
+	// sequence number of the next entry to be appended.
+	// head is advanced lazily, so it may trail tail by more
+	// than the size of the log while appends are in flight.
+	head atomic.Int64
+	tail atomic.Int64
+
+	read   int64
+	locker sync.Mutex
+}
+
+// NewAtomicMemLog returns a new AtomicMemLog that will
+// not grow beyond size entries.  If size is not positive
+// every entry is discarded.
+func NewAtomicMemLog[T any](size int) *AtomicMemLog[T] {
+	size = max(size, 0)
+	return &AtomicMemLog[T]{
+		slots: make([]atomic.Pointer[sequenced[T]], size),
+	}
+}
+
+// Cap returns the maximum number of
+// entries the log will hold.
+func (a *AtomicMemLog[T]) Cap() int {
+	return len(a.slots)
+}
+
+// Len returns the number of entries in the log, including
+// any that are still being appended.
+func (a *AtomicMemLog[T]) Len() int {
+	// head is loaded first; it never passes a tail
+	// loaded after it
+	head := a.head.Load()
+	tail := a.tail.Load()
+	return int(tail - a.oldest(head, tail))
+}
+
+// Append will add item to the log, overwriting the oldest
+// entry if the log is full.  Append never blocks.
+func (a *AtomicMemLog[T]) Append(item T) {
+	if len(a.slots) == 0 {
+		return
+	}
+
+	var seq int64
+	for {
+		seq = a.tail.Load()
+		if a.tail.CompareAndSwap(seq, seq+1) {
+			break
+		}
+	}
+	a.raiseHead(seq + 1 - int64(len(a.slots)))
+
+	entry := &sequenced[T]{seq: uint64(seq), value: item}
+	slot := a.slot(seq)
+	for {
+		old := slot.Load()
+		// a slower producer must not overwrite a newer entry
+		if old != nil && old.seq > entry.seq {
+			return
+		}
+		if slot.CompareAndSwap(old, entry) {
+			return
+		}
+	}
+}
+
+// Slice returns the contents of the log as a slice.  The
+// slice is ordered from oldest item to the newest.
+func (a *AtomicMemLog[T]) Slice() []T {
+	return a.SliceN(allElements)
+}
+
+// SliceN returns the last 'N' items from the log.  The
+// slice is ordered from oldest item to the newest.
+func (a *AtomicMemLog[T]) SliceN(n int) []T {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+
+	head := a.head.Load()
+	tail := a.tail.Load()
+	lo := a.oldest(head, tail)
+	if n > allElements && int64(n) < tail-lo {
+		lo = tail - int64(n)
+	}
+
+	slice := make([]T, 0, tail-lo)
+	for seq := lo; seq < tail; seq++ {
+		if entry := a.slot(seq).Load(); entry != nil && entry.seq == uint64(seq) {
+			slice = append(slice, entry.value)
+		}
+	}
+
+	return slice
+}
+
+// Drain returns the entries appended since the previous call
+// to Drain that have not been overwritten or cleared.  The
+// slice is ordered from oldest item to the newest.
+func (a *AtomicMemLog[T]) Drain() []T {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+
+	head := a.head.Load()
+	tail := a.tail.Load()
+	lo := max(a.oldest(head, tail), a.read)
+
+	var slice []T
+	seq := lo
+	for ; seq < tail; seq++ {
+		entry := a.slot(seq).Load()
+		if entry == nil || entry.seq < uint64(seq) {
+			// still being appended; resume here next time
+			break
+		}
+		if entry.seq == uint64(seq) {
+			slice = append(slice, entry.value)
+		}
+	}
+	a.read = seq
+
+	return slice
+}
+
+// Clear removes the entries that have been appended
+// to the log.
+func (a *AtomicMemLog[T]) Clear() {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.raiseHead(a.tail.Load())
+}
+
+// raiseHead advances head to seq unless it
+// is already at or beyond it.
+func (a *AtomicMemLog[T]) raiseHead(seq int64) {
+	for {
+		head := a.head.Load()
+		if head >= seq || a.head.CompareAndSwap(head, seq) {
+			return
+		}
+	}
+}
+
+// oldest returns the sequence number of the oldest entry
+// that may still be in the log given head and tail.  The
+// result is never after tail.
+func (a *AtomicMemLog[T]) oldest(head, tail int64) int64 {
+	lo := max(head, tail-int64(len(a.slots)))
+	return min(lo, tail)
+}
+
+// slot returns the slot holding the entry with
+// sequence number seq.
+func (a *AtomicMemLog[T]) slot(seq int64) *atomic.Pointer[sequenced[T]] {
+	return &a.slots[seq%int64(len(a.slots))]
+}
@@ -0,0 +1,268 @@
+package memlog
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_atomic_memlog_slice(t *testing.T) {
+	// given an atomic log
+	log := NewAtomicMemLog[int](3)
+	assert.Equal(t, 3, log.Cap())
+	assert.Empty(t, log.Slice())
+
+	// when more entries are appended than it holds
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	// then the most recent entries are returned in order
+	assert.Equal(t, []int{3, 4, 5}, log.Slice())
+}
+
+func Test_atomic_memlog_drain(t *testing.T) {
+	// given an atomic log that has been drained
+	log := NewAtomicMemLog[int](3)
+	log.Append(1)
+	log.Append(2)
+	assert.Equal(t, []int{1, 2}, log.Drain())
+	assert.Empty(t, log.Drain())
+
+	// when more entries are appended than it holds
+	for i := 3; i <= 7; i++ {
+		log.Append(i)
+	}
+
+	// then only entries that were not overwritten are drained
+	assert.Equal(t, []int{5, 6, 7}, log.Drain())
+}
+
+func Test_atomic_memlog_slice_n_len_and_clear(t *testing.T) {
+	// given an atomic log that has wrapped
+	log := NewAtomicMemLog[int](3)
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	// then the newest entries can be read
+	assert.Equal(t, 3, log.Len())
+	assert.Equal(t, []int{4, 5}, log.SliceN(2))
+	assert.Equal(t, []int{3, 4, 5}, log.SliceN(10))
+
+	// when it is cleared
+	log.Clear()
+
+	// then it is empty until more entries are appended
+	assert.Equal(t, 0, log.Len())
+	assert.Empty(t, log.Slice())
+	assert.Empty(t, log.Drain())
+
+	log.Append(6)
+	assert.Equal(t, 1, log.Len())
+	assert.Equal(t, []int{6}, log.Slice())
+	assert.Equal(t, []int{6}, log.Drain())
+}
+
+func Test_atomic_memlog_zero_size(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		// given an atomic log with no room for entries
+		log := NewAtomicMemLog[int](size)
+
+		// when entries are appended
+		assert.NotPanics(t, func() {
+			log.Append(1)
+			log.Append(2)
+		})
+
+		// then they are discarded
+		assert.Equal(t, 0, log.Cap())
+		assert.Equal(t, 0, log.Len())
+		assert.Empty(t, log.Slice())
+		assert.Empty(t, log.Drain())
+	}
+}
+
+func Test_atomic_memlog_concurrent_readers(t *testing.T) {
+	log := NewAtomicMemLog[int](16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				log.Append(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.LessOrEqual(t, len(log.Slice()), 16)
+				log.Drain()
+				log.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, log.Slice(), 16)
+}
+
+func Test_atomic_memlog_len_during_clear(t *testing.T) {
+	// given producers appending while the log is cleared
+	log := NewAtomicMemLog[int](8)
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					log.Append(1)
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				log.Clear()
+			}
+		}
+	}()
+
+	// then Len stays within the size of the log
+	for i := 0; i < 10000; i++ {
+		n := log.Len()
+		if n < 0 || n > log.Cap() {
+			assert.Failf(t, "Len out of range", "got %d", n)
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func Test_atomic_memlog_concurrent_producers(t *testing.T) {
+	type entry struct {
+		producer, n, check int
+	}
+
+	// given several producers and a single consumer
+	const producers, perProducer = 8, 5000
+	log := NewAtomicMemLog[entry](64)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for n := 0; n < perProducer; n++ {
+				log.Append(entry{producer: p, n: n, check: p*perProducer + n})
+			}
+		}(p)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// when the consumer drains while producers append
+	var drained []entry
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		drained = append(drained, log.Drain()...)
+	}
+
+	// then no entry is torn or delivered twice, and each
+	// producer's entries are in order
+	last := make(map[int]int)
+	seen := make(map[int]bool)
+	for _, e := range drained {
+		assert.Equal(t, e.producer*perProducer+e.n, e.check)
+		assert.False(t, seen[e.check])
+		seen[e.check] = true
+		if prev, ok := last[e.producer]; ok {
+			assert.Less(t, prev, e.n)
+		}
+		last[e.producer] = e.n
+	}
+	assert.NotEmpty(t, drained)
+}
+
+// benchmarkAppendLatency reports the 99th percentile
+// latency of calls to append from parallel goroutines.
+func benchmarkAppendLatency(b *testing.B, appendFn func(int)) {
+	var mu sync.Mutex
+	var latencies []time.Duration
+
+	b.RunParallel(func(pb *testing.PB) {
+		local := make([]time.Duration, 0, 1024)
+		for pb.Next() {
+			start := time.Now()
+			appendFn(1)
+			local = append(local, time.Since(start))
+		}
+		mu.Lock()
+		latencies = append(latencies, local...)
+		mu.Unlock()
+	})
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	if len(latencies) > 0 {
+		b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns")
+	}
+}
+
+func Benchmark_memlog_append_latency(b *testing.B) {
+	log := NewMemLog[int](1000)
+	benchmarkAppendLatency(b, log.Append)
+}
+
+func Benchmark_atomic_memlog_append_latency(b *testing.B) {
+	log := NewAtomicMemLog[int](1000)
+	benchmarkAppendLatency(b, log.Append)
+}
+
+// Benchmark_atomic_memlog_append_contended appends from 16
+// goroutines per CPU to show the effect of contention on the
+// lock, comparing AtomicMemLog with a MemLog.
+func Benchmark_atomic_memlog_append_contended(b *testing.B) {
+	benchmarks := []struct {
+		name   string
+		append func(int)
+	}{
+		{"atomic", NewAtomicMemLog[int](1000).Append},
+		{"mutex", NewMemLog[int](1000).Append},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.SetParallelism(16)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					bm.append(1)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package memlog
+
+import "sync"
+
+// BroadcastLog appends each entry to a set of MemLog targets
+// that can change while the log is in use.  Unlike MultiLog,
+// targets may be added and removed at any time.
+//
+// BroadcastLog is thread-safe
+type BroadcastLog[T any] struct {
+	locker  sync.RWMutex
+	targets []*MemLog[T]
+}
+
+// NewBroadcastLog returns a BroadcastLog that appends
+// to each of targets.
+func NewBroadcastLog[T any](targets ...*MemLog[T]) *BroadcastLog[T] {
+	b := &BroadcastLog[T]{}
+	for _, target := range targets {
+		b.Add(target)
+	}
+	return b
+}
+
+// Append adds item to each target in the order the targets
+// were added.  Each target is locked only while item is being
+// added to it.  Targets cannot be added or removed until
+// Append returns.
+func (b *BroadcastLog[T]) Append(item T) {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+
+	for _, target := range b.targets {
+		target.Append(item)
+	}
+}
+
+// Add causes entries appended after it returns to also be
+// appended to target.  Adding a target more than once has
+// no effect.
+func (b *BroadcastLog[T]) Add(target *MemLog[T]) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	if target == nil || b.indexOf(target) >= 0 {
+		return
+	}
+	b.targets = append(b.targets, target)
+}
+
+// Remove stops entries appended after it returns
+// from being appended to target.
+func (b *BroadcastLog[T]) Remove(target *MemLog[T]) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	if i := b.indexOf(target); i >= 0 {
+		b.targets = append(b.targets[:i:i], b.targets[i+1:]...)
+	}
+}
+
+// indexOf returns the position of target in
+// targets, or -1 if it is not present.
+// The caller must hold the lock.
+func (b *BroadcastLog[T]) indexOf(target *MemLog[T]) int {
+	for i, t := range b.targets {
+		if t == target {
+			return i
+		}
+	}
+	return -1
+}
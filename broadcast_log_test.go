@@ -0,0 +1,95 @@
+package memlog
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_broadcast_log_appends_to_all_targets(t *testing.T) {
+	// given two targets of different sizes
+	recent := NewMemLog[int](2)
+	history := NewMemLog[int](10)
+	log := NewBroadcastLog(recent, history)
+
+	// when entries are appended
+	for i := 1; i <= 4; i++ {
+		log.Append(i)
+	}
+
+	// then each target receives every entry up to its size
+	assert.Equal(t, []int{3, 4}, recent.Slice())
+	assert.Equal(t, []int{1, 2, 3, 4}, history.Slice())
+}
+
+func Test_broadcast_log_add(t *testing.T) {
+	// given a target added after entries were appended
+	first := NewMemLog[int](10)
+	second := NewMemLog[int](10)
+	log := NewBroadcastLog(first)
+	log.Append(1)
+
+	log.Add(second)
+	log.Add(second)
+	log.Append(2)
+
+	// then it only receives later entries, once each
+	assert.Equal(t, []int{1, 2}, first.Slice())
+	assert.Equal(t, []int{2}, second.Slice())
+}
+
+func Test_broadcast_log_remove(t *testing.T) {
+	// given two targets
+	first := NewMemLog[int](10)
+	second := NewMemLog[int](10)
+	log := NewBroadcastLog(first, second)
+	log.Append(1)
+
+	// when one is removed
+	log.Remove(first)
+	log.Append(2)
+
+	// then forwarding to it stops
+	assert.Equal(t, []int{1}, first.Slice())
+	assert.Equal(t, []int{1, 2}, second.Slice())
+
+	// and removing an unknown target has no effect
+	log.Remove(NewMemLog[int](10))
+	log.Append(3)
+	assert.Equal(t, []int{1, 2, 3}, second.Slice())
+}
+
+func Test_broadcast_log_without_targets(t *testing.T) {
+	log := NewBroadcastLog[int](nil)
+
+	assert.NotPanics(t, func() { log.Append(1) })
+}
+
+func Test_broadcast_log_concurrent(t *testing.T) {
+	// given a target that is repeatedly added and removed
+	stable := NewMemLog[int](1000)
+	flapping := NewMemLog[int](1000)
+	log := NewBroadcastLog(stable)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			log.Append(i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			log.Add(flapping)
+			log.Remove(flapping)
+		}
+	}()
+	wg.Wait()
+
+	// then the stable target receives every entry
+	assert.Equal(t, 500, stable.Len())
+	assert.LessOrEqual(t, flapping.Len(), 500)
+}
@@ -0,0 +1,99 @@
+package memlog
+
+import "sync"
+
+// BytesLog is an io.Writer that stores each chunk written to
+// it in a MemLog exactly as received, without any conversion
+// or trimming.  This makes it suitable for capturing binary
+// or terminal control output.
+//
+// BytesLog is thread-safe
+type BytesLog struct {
+	Buffer       *MemLog[[]byte]
+	maxChunk     int
+	evictByBytes bool
+	locker       sync.Mutex
+}
+
+// BytesLogOption configures optional behavior of a BytesLog.
+type BytesLogOption func(*BytesLog)
+
+// WithMaxChunkSize splits writes larger than max bytes into
+// several chunks of at most max bytes each.
+func WithMaxChunkSize(max int) BytesLogOption {
+	return func(b *BytesLog) {
+		b.maxChunk = max
+	}
+}
+
+// WithEvictByBytes causes the size passed to NewBytesLog to be
+// treated as a limit on the total number of bytes stored rather
+// than on the number of chunks.  The oldest chunks are removed
+// until the total fits, although the newest chunk is always kept.
+func WithEvictByBytes() BytesLogOption {
+	return func(b *BytesLog) {
+		b.evictByBytes = true
+	}
+}
+
+// NewBytesLog returns a BytesLog that will not grow
+// beyond size chunks.
+func NewBytesLog(size int, opts ...BytesLogOption) *BytesLog {
+	b := &BytesLog{}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.evictByBytes {
+		b.Buffer = NewCappedLog(size, func(chunk []byte) int {
+			return len(chunk)
+		})
+		b.Buffer.oversized = true
+	} else {
+		b.Buffer = NewMemLog[[]byte](size)
+	}
+
+	return b
+}
+
+// Write provides an implementation of the io.Writer
+// interface that stores a copy of p in the buffer.
+func (b *BytesLog) Write(p []byte) (n int, err error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	for data := p; len(data) > 0; {
+		size := len(data)
+		if b.maxChunk > 0 && size > b.maxChunk {
+			size = b.maxChunk
+		}
+		b.store(data[:size])
+		data = data[size:]
+	}
+
+	return len(p), nil
+}
+
+// Bytes returns the contents of all chunks in
+// the buffer concatenated from oldest to newest.
+func (b *BytesLog) Bytes() []byte {
+	chunks := b.Buffer.Slice()
+
+	size := 0
+	for _, chunk := range chunks {
+		size += len(chunk)
+	}
+
+	data := make([]byte, 0, size)
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+
+	return data
+}
+
+// store appends a copy of chunk to the buffer.
+func (b *BytesLog) store(chunk []byte) {
+	b.Buffer.Append(append([]byte(nil), chunk...))
+}
@@ -0,0 +1,92 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bytes_log_stores_chunks_unmodified(t *testing.T) {
+	// given a bytes log
+	bl := NewBytesLog(10)
+
+	// when chunks containing control bytes are written
+	bl.Write([]byte("\x1b[2K\r"))
+	bl.Write([]byte{0x00, 0xff, '\n'})
+
+	// then chunk boundaries and contents are preserved
+	assert.Equal(t, [][]byte{[]byte("\x1b[2K\r"), {0x00, 0xff, '\n'}}, bl.Buffer.Slice())
+	assert.Equal(t, []byte("\x1b[2K\r\x00\xff\n"), bl.Bytes())
+}
+
+func Test_bytes_log_copies_written_data(t *testing.T) {
+	// given a bytes log
+	bl := NewBytesLog(10)
+
+	// when the caller reuses its buffer after writing
+	p := []byte("first")
+	bl.Write(p)
+	copy(p, "XXXXX")
+
+	// then the stored chunk is unaffected
+	assert.Equal(t, []byte("first"), bl.Buffer.Slice()[0])
+}
+
+func Test_bytes_log_evicts_by_chunk_count(t *testing.T) {
+	bl := NewBytesLog(2)
+
+	bl.Write([]byte("one"))
+	bl.Write([]byte("two"))
+	bl.Write([]byte("three"))
+
+	assert.Equal(t, []byte("twothree"), bl.Bytes())
+}
+
+func Test_bytes_log_evicts_by_total_bytes(t *testing.T) {
+	// given a bytes log limited to 10 bytes
+	bl := NewBytesLog(10, WithEvictByBytes())
+
+	// when chunks totalling more than 10 bytes are written
+	bl.Write([]byte("1234"))
+	bl.Write([]byte("5678"))
+	bl.Write([]byte("90"))
+	assert.Equal(t, 3, bl.Buffer.Len())
+	bl.Write([]byte("abc"))
+
+	// then the oldest chunks are removed until the total fits
+	assert.Equal(t, [][]byte{[]byte("5678"), []byte("90"), []byte("abc")}, bl.Buffer.Slice())
+
+	// and a chunk larger than the limit is kept on its own
+	bl.Write([]byte("0123456789abc"))
+	assert.Equal(t, [][]byte{[]byte("0123456789abc")}, bl.Buffer.Slice())
+}
+
+func Test_bytes_log_evict_by_bytes_after_buffer_cleared(t *testing.T) {
+	// given a full bytes log limited to 8 bytes
+	bl := NewBytesLog(8, WithEvictByBytes())
+	bl.Write([]byte("12345678"))
+
+	// when its buffer is cleared directly and written to again
+	bl.Buffer.Clear()
+	for _, chunk := range []string{"a", "b", "c", "d"} {
+		bl.Write([]byte(chunk))
+	}
+
+	// then the whole byte limit is available
+	assert.Equal(t, []byte("abcd"), bl.Bytes())
+	assert.Equal(t, 4, bl.Buffer.Len())
+	assert.Equal(t, 4, bl.Buffer.ByteLen())
+}
+
+func Test_bytes_log_max_chunk_size(t *testing.T) {
+	// given a bytes log with a maximum chunk size
+	bl := NewBytesLog(10, WithMaxChunkSize(4))
+
+	// when a larger write is made
+	n, err := bl.Write([]byte("0123456789"))
+
+	// then it is split into chunks of at most 4 bytes
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, [][]byte{[]byte("0123"), []byte("4567"), []byte("89")}, bl.Buffer.Slice())
+}
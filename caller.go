@@ -0,0 +1,38 @@
+package memlog
+
+import (
+	"runtime"
+	"strconv"
+)
+
+// Caller identifies the source code location
+// that added an entry to a log.
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// String returns the location as file:line.
+func (c Caller) String() string {
+	return c.File + ":" + strconv.Itoa(c.Line)
+}
+
+// callerFrame returns the location of the function skip
+// frames above the caller of callerFrame, or nil if the
+// stack is not that deep.
+func callerFrame(skip int) *Caller {
+	var pc [1]uintptr
+
+	// skip runtime.Callers and callerFrame
+	if runtime.Callers(skip+2, pc[:]) == 0 {
+		return nil
+	}
+
+	frame, _ := runtime.CallersFrames(pc[:]).Next()
+	return &Caller{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
@@ -0,0 +1,31 @@
+package memlog
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_caller_frame(t *testing.T) {
+	// given the location of this call
+	_, file, line, _ := runtime.Caller(0)
+
+	// when the frame of the caller is captured
+	caller := callerFrame(0)
+
+	// then it points at this test
+	assert.Equal(t, file, caller.File)
+	assert.Equal(t, line+3, caller.Line)
+	assert.Equal(t, "github.com/yabosh/memlog.Test_caller_frame", caller.Function)
+}
+
+func Test_caller_frame_too_deep(t *testing.T) {
+	assert.Nil(t, callerFrame(1000))
+}
+
+func Test_caller_string(t *testing.T) {
+	caller := Caller{File: filepath.Join("src", "main.go"), Line: 12}
+	assert.Equal(t, filepath.Join("src", "main.go")+":12", caller.String())
+}
@@ -0,0 +1,24 @@
+package memlog
+
+import "math"
+
+// NewCappedLog returns a new MemLog that is bounded by the
+// total byte cost of its entries rather than their number.
+// sizer returns the cost of an entry.  When appending an
+// entry would take the log beyond maxBytes, the oldest
+// entries are removed until the new entry fits.  An entry
+// that costs more than maxBytes on its own is discarded.
+//
+// Cap returns maxBytes and ByteLen returns the current
+// cost of the entries in the log.
+func NewCappedLog[T any](maxBytes int, sizer func(T) int) *MemLog[T] {
+	m := NewMemLog[T](math.MaxInt, WithSizer(sizer))
+	m.maxBytes = maxBytes
+	return m
+}
+
+// ByteLen returns the total cost of the entries in the
+// log, as reported by its sizer.
+func (m *MemLog[T]) ByteLen() int {
+	return m.Bytes()
+}
@@ -0,0 +1,76 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func strLen(s string) int {
+	return len(s)
+}
+
+func Test_capped_log_evicts_by_bytes(t *testing.T) {
+	// given a capped log with a budget of 10 bytes
+	log := NewCappedLog[string](10, strLen)
+
+	// when entries totalling the budget are added
+	log.Append("aaaa")
+	log.Append("bbb")
+	log.Append("ccc")
+
+	// then nothing is evicted
+	assert.Equal(t, 10, log.Cap())
+	assert.Equal(t, 3, log.Len())
+	assert.Equal(t, 10, log.ByteLen())
+	assert.Equal(t, []string{"aaaa", "bbb", "ccc"}, log.Slice())
+}
+
+func Test_capped_log_multi_entry_eviction(t *testing.T) {
+	// given a capped log holding several small entries
+	log := NewCappedLog[string](10, strLen)
+	log.Append("aa")
+	log.Append("bb")
+	log.Append("cc")
+	log.Append("dd")
+
+	// when an entry needing the space of several entries is added
+	log.Append("eeeeee")
+
+	// then the oldest entries are evicted until it fits
+	assert.Equal(t, []string{"cc", "dd", "eeeeee"}, log.Slice())
+	assert.Equal(t, 10, log.ByteLen())
+	assert.Equal(t, uint64(2), log.Stats().TotalEvictions)
+
+	// and an entry using the whole budget evicts everything else
+	log.Append("ffffffffff")
+	assert.Equal(t, []string{"ffffffffff"}, log.Slice())
+	assert.Equal(t, 10, log.ByteLen())
+}
+
+func Test_capped_log_discards_oversized_entry(t *testing.T) {
+	// given a capped log with entries
+	log := NewCappedLog[string](4, strLen)
+	log.Append("ab")
+
+	// when an entry larger than the budget is added
+	log.Append("abcde")
+
+	// then it is discarded and the log is unchanged
+	assert.Equal(t, []string{"ab"}, log.Slice())
+	assert.Equal(t, 2, log.ByteLen())
+	assert.Equal(t, uint64(1), log.Stats().TotalEvictions)
+}
+
+func Test_capped_log_pop_frees_bytes(t *testing.T) {
+	log := NewCappedLog[string](6, strLen)
+	log.Append("abc")
+	log.Append("def")
+
+	_, ok := log.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, log.ByteLen())
+
+	log.Append("ghi")
+	assert.Equal(t, []string{"def", "ghi"}, log.Slice())
+}
@@ -0,0 +1,61 @@
+package memlog
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to the time-based
+// features of the package, such as timestamps, age-based
+// retention and rate limiting.  Use WithClock to replace
+// the system clock, for instance with a FakeClock in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock used unless another is
+// provided.  It reports the wall clock time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FakeClock is a Clock whose time only changes when it
+// is set or advanced.
+//
+// FakeClock is thread-safe
+type FakeClock struct {
+	now    time.Time
+	locker sync.Mutex
+}
+
+// NewFakeClock returns a FakeClock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set sets the clock's current time to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	c.now = t
+}
@@ -0,0 +1,65 @@
+package memlog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fake_clock(t *testing.T) {
+	// given a fake clock
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	// when it is advanced and set
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+
+	clock.Set(start)
+
+	// then it reports the new time
+	assert.Equal(t, start, clock.Now())
+}
+
+func Test_fake_clock_concurrent_use(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clock.Advance(time.Second)
+			clock.Now()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, time.Time{}.Add(10*time.Second), clock.Now())
+}
+
+func Test_memlog_with_clock(t *testing.T) {
+	// given a log using a fake clock
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	log := NewMemLog(10, WithClock[string](clock))
+
+	// when an entry is appended and the clock is advanced
+	log.Append("a")
+	clock.Advance(5 * time.Second)
+
+	// then the age is measured by the clock
+	assert.Equal(t, 5*time.Second, log.Age())
+}
+
+func Test_string_log_with_clock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	sl := NewStringLog(10, WithStringClock(clock))
+
+	sl.Write([]byte("line\n"))
+	clock.Advance(time.Minute)
+
+	assert.Equal(t, time.Minute, sl.Buffer.Age())
+}
@@ -0,0 +1,63 @@
+package memlog
+
+// CoalescedEntry is a value along with the number of
+// times it was appended consecutively.
+type CoalescedEntry[T any] struct {
+	Value T
+	Count int
+}
+
+// CoalescingLog is a MemLog that merges consecutive identical
+// entries into a single entry with a count.  When the same
+// value is appended repeatedly the count of the most recent
+// entry is incremented rather than a new entry being stored.
+//
+// Merged appends are not delivered to subscribers of Buffer
+// and are not counted in its Stats.
+//
+// CoalescingLog is thread-safe
+type CoalescingLog[T comparable] struct {
+	Buffer *MemLog[CoalescedEntry[T]]
+}
+
+// NewCoalescingLog returns a new CoalescingLog that will
+// not grow beyond size distinct runs of entries.
+func NewCoalescingLog[T comparable](size int) *CoalescingLog[T] {
+	merge := func(last, item CoalescedEntry[T]) (CoalescedEntry[T], bool) {
+		if last.Value != item.Value {
+			return last, false
+		}
+		last.Count += item.Count
+		return last, true
+	}
+
+	return &CoalescingLog[T]{
+		Buffer: NewMemLog(size, withMerge(merge)),
+	}
+}
+
+// Append adds v to the log, incrementing the count of the
+// most recent entry if it has the same value.
+func (c *CoalescingLog[T]) Append(v T) {
+	c.Buffer.Append(CoalescedEntry[T]{Value: v, Count: 1})
+}
+
+// Slice returns the entries in the log.  The slice
+// is ordered from oldest item to the newest.
+func (c *CoalescingLog[T]) Slice() []CoalescedEntry[T] {
+	return c.Buffer.Slice()
+}
+
+// Total returns the number of appends represented by
+// the entries currently in the log.
+func (c *CoalescingLog[T]) Total() int {
+	total := 0
+
+	c.Buffer.locker.Lock()
+	defer c.Buffer.locker.Unlock()
+	c.Buffer.forEachN(allElements, func(entry CoalescedEntry[T]) {
+		total += entry.Count
+	})
+
+	return total
+}
@@ -0,0 +1,57 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_coalescing_log_alternating_values(t *testing.T) {
+	// given a coalescing log
+	log := NewCoalescingLog[string](10)
+
+	// when values alternate
+	for _, v := range []string{"a", "b", "a", "b"} {
+		log.Append(v)
+	}
+
+	// then each append is stored separately
+	assert.Equal(t, []CoalescedEntry[string]{
+		{Value: "a", Count: 1},
+		{Value: "b", Count: 1},
+		{Value: "a", Count: 1},
+		{Value: "b", Count: 1},
+	}, log.Slice())
+}
+
+func Test_coalescing_log_long_runs(t *testing.T) {
+	// given a coalescing log
+	log := NewCoalescingLog[string](10)
+
+	// when long runs of identical values are appended
+	for i := 0; i < 1000; i++ {
+		log.Append("health check OK")
+	}
+	log.Append("health check FAILED")
+	for i := 0; i < 3; i++ {
+		log.Append("health check OK")
+	}
+
+	// then each run is stored once with its count
+	assert.Equal(t, []CoalescedEntry[string]{
+		{Value: "health check OK", Count: 1000},
+		{Value: "health check FAILED", Count: 1},
+		{Value: "health check OK", Count: 3},
+	}, log.Slice())
+	assert.Equal(t, 1004, log.Total())
+}
+
+func Test_coalescing_log_evicts_runs(t *testing.T) {
+	log := NewCoalescingLog[int](2)
+	for _, v := range []int{1, 1, 2, 2, 2, 3} {
+		log.Append(v)
+	}
+
+	assert.Equal(t, []CoalescedEntry[int]{{Value: 2, Count: 3}, {Value: 3, Count: 1}}, log.Slice())
+	assert.Equal(t, 4, log.Total())
+}
@@ -0,0 +1,188 @@
+package memlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape sequences used by DumpColor.
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorGreen  = "\x1b[32m"
+	colorGray   = "\x1b[90m"
+)
+
+// levelColors maps each level to the escape sequence
+// used to highlight it.
+var levelColors = map[Level]string{
+	LevelDebug: colorGray,
+	LevelInfo:  colorGreen,
+	LevelWarn:  colorYellow,
+	LevelError: colorRed,
+}
+
+// dumpConfig holds the settings applied by DumpOptions.
+type dumpConfig struct {
+	color    *bool
+	width    int
+	terminal bool
+}
+
+// DumpOption configures DumpColor.
+type DumpOption func(*dumpConfig)
+
+// WithColor forces color on or off.  By default color is
+// enabled only when w is a terminal and the NO_COLOR
+// environment variable is not set.
+func WithColor(enabled bool) DumpOption {
+	return func(c *dumpConfig) {
+		c.color = &enabled
+	}
+}
+
+// WithLineWidth truncates lines longer than width runes,
+// ending them with "...".  A width of 0 disables truncation.
+func WithLineWidth(width int) DumpOption {
+	return func(c *dumpConfig) {
+		c.width = width
+	}
+}
+
+// WithTerminalWidth truncates lines to the width of the
+// terminal as reported by the COLUMNS environment variable.
+// Lines are not truncated if COLUMNS is not set.
+func WithTerminalWidth() DumpOption {
+	return func(c *dumpConfig) {
+		c.terminal = true
+	}
+}
+
+// DumpColor writes the contents of log to w, oldest to newest,
+// one entry per line, highlighting each line by the level token
+// it starts with, such as "ERROR" or "WARN".  Lines without a
+// recognized level are written uncolored.  DumpColor stops at
+// and returns the first write error.
+func DumpColor(w io.Writer, log *MemLog[string], opts ...DumpOption) error {
+	cfg := newDumpConfig(w, opts)
+
+	for _, line := range log.Slice() {
+		level, ok := detectLevel(line)
+		if err := cfg.writeLine(w, line, level, ok); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DumpColor writes the contents of the log to w, oldest to
+// newest, as the level name followed by the entry, highlighted
+// by level.  Each entry is formatted using format or, if format
+// is nil, using the %v verb.  DumpColor stops at and returns
+// the first write error.
+func (l *LevelLog[T]) DumpColor(w io.Writer, format func(T) string, opts ...DumpOption) error {
+	if format == nil {
+		format = func(item T) string {
+			return fmt.Sprintf("%v", item)
+		}
+	}
+
+	cfg := newDumpConfig(w, opts)
+
+	for _, entry := range l.Buffer.Slice() {
+		line := entry.Level.String() + " " + format(entry.Value)
+		if err := cfg.writeLine(w, line, entry.Level, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newDumpConfig applies opts, filling in the defaults for w.
+func newDumpConfig(w io.Writer, opts []DumpOption) *dumpConfig {
+	cfg := &dumpConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.color == nil {
+		enabled := isTerminal(w) && os.Getenv("NO_COLOR") == ""
+		cfg.color = &enabled
+	}
+
+	if cfg.terminal {
+		if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+			cfg.width = cols
+		}
+	}
+
+	return cfg
+}
+
+// writeLine writes line to w, truncated and colored as
+// configured.  Truncation is applied before coloring so
+// escape sequences do not count toward the width.
+func (c *dumpConfig) writeLine(w io.Writer, line string, level Level, hasLevel bool) error {
+	if c.width > 0 {
+		line = truncateWidth(line, c.width)
+	}
+
+	if color, ok := levelColors[level]; ok && hasLevel && *c.color {
+		line = color + line + colorReset
+	}
+
+	_, err := io.WriteString(w, line+"\n")
+	return err
+}
+
+// detectLevel returns the level named by the first word of
+// line.  Surrounding brackets and a trailing colon are ignored
+// so "[WARN]" and "error:" are both recognized.
+func detectLevel(line string) (Level, bool) {
+	token := strings.TrimLeft(line, " \t")
+	if idx := strings.IndexAny(token, " \t"); idx >= 0 {
+		token = token[:idx]
+	}
+	token = strings.TrimSuffix(token, ":")
+	token = strings.TrimSuffix(strings.TrimPrefix(token, "["), "]")
+
+	level, ok := defaultLevelTokens[strings.ToUpper(token)]
+	return level, ok
+}
+
+// truncateWidth shortens line to at most width runes,
+// including the "..." marker.
+func truncateWidth(line string, width int) string {
+	runes := []rune(line)
+	switch {
+	case len(runes) <= width:
+		return line
+	case width <= len("..."):
+		return string(runes[:width])
+	default:
+		return truncateRunes(line, width-len("..."))
+	}
+}
+
+// isTerminal reports whether w is a character device
+// such as a terminal.  It is a best-effort check that
+// only recognizes *os.File.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
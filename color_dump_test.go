@@ -0,0 +1,136 @@
+package memlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dump_color_forced_on(t *testing.T) {
+	// given lines at each level and one without a level
+	log := NewMemLog[string](10)
+	log.Append("DEBUG cache miss")
+	log.Append("INFO started")
+	log.Append("[WARN] disk low")
+	log.Append("error: connection refused")
+	log.Append("plain line")
+
+	// when the log is dumped with color forced on
+	var out strings.Builder
+	assert.NoError(t, DumpColor(&out, log, WithColor(true)))
+
+	// then each level line is wrapped in its escape sequence
+	assert.Equal(t,
+		"\x1b[90mDEBUG cache miss\x1b[0m\n"+
+			"\x1b[32mINFO started\x1b[0m\n"+
+			"\x1b[33m[WARN] disk low\x1b[0m\n"+
+			"\x1b[31merror: connection refused\x1b[0m\n"+
+			"plain line\n",
+		out.String())
+}
+
+func Test_dump_color_forced_off(t *testing.T) {
+	// given lines with levels
+	log := NewMemLog[string](10)
+	log.Append("WARN disk low")
+	log.Append("ERROR failed")
+
+	// when the log is dumped with color forced off
+	var out strings.Builder
+	assert.NoError(t, DumpColor(&out, log, WithColor(false)))
+
+	// then no escape sequences are written
+	assert.Equal(t, "WARN disk low\nERROR failed\n", out.String())
+	assert.NotContains(t, out.String(), "\x1b[")
+}
+
+func Test_dump_color_default_off_for_non_terminal(t *testing.T) {
+	log := NewMemLog[string](10)
+	log.Append("ERROR failed")
+
+	// a strings.Builder is not a terminal
+	var out strings.Builder
+	assert.NoError(t, DumpColor(&out, log))
+	assert.Equal(t, "ERROR failed\n", out.String())
+
+	// nor is a regular file
+	f, err := os.Create(filepath.Join(t.TempDir(), "dump.txt"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.NoError(t, DumpColor(f, log))
+	data, err := os.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "ERROR failed\n", string(data))
+}
+
+func Test_dump_color_line_width(t *testing.T) {
+	// given lines shorter than, equal to and longer than the width
+	log := NewMemLog[string](10)
+	log.Append("ERROR short")
+	log.Append("ERROR 12345")
+	log.Append("ERROR connection refused")
+	log.Append("WARN héllo wörld")
+
+	// when the log is dumped with a width of 11
+	var out strings.Builder
+	assert.NoError(t, DumpColor(&out, log, WithColor(true), WithLineWidth(11)))
+
+	// then long lines are truncated to 11 runes before coloring
+	assert.Equal(t,
+		"\x1b[31mERROR short\x1b[0m\n"+
+			"\x1b[31mERROR 12345\x1b[0m\n"+
+			"\x1b[31mERROR co...\x1b[0m\n"+
+			"\x1b[33mWARN hél...\x1b[0m\n",
+		out.String())
+}
+
+func Test_dump_color_terminal_width(t *testing.T) {
+	log := NewMemLog[string](10)
+	log.Append("INFO a long line")
+
+	// given COLUMNS is set
+	t.Setenv("COLUMNS", "8")
+
+	var out strings.Builder
+	assert.NoError(t, DumpColor(&out, log, WithTerminalWidth()))
+	assert.Equal(t, "INFO ...\n", out.String())
+
+	// given COLUMNS is not a number
+	t.Setenv("COLUMNS", "wide")
+
+	out.Reset()
+	assert.NoError(t, DumpColor(&out, log, WithTerminalWidth()))
+	assert.Equal(t, "INFO a long line\n", out.String())
+}
+
+func Test_dump_color_level_log(t *testing.T) {
+	// given a level log
+	log := NewLevelLog[int](10)
+	log.Info(1)
+	log.Error(2)
+
+	// when dumped with color on and off
+	var on, off strings.Builder
+	assert.NoError(t, log.DumpColor(&on, nil, WithColor(true)))
+	assert.NoError(t, log.DumpColor(&off, nil, WithColor(false)))
+
+	// then lines are prefixed with the level and colored only when enabled
+	assert.Equal(t, "\x1b[32mINFO 1\x1b[0m\n\x1b[31mERROR 2\x1b[0m\n", on.String())
+	assert.Equal(t, "INFO 1\nERROR 2\n", off.String())
+}
+
+func Test_dump_color_write_error(t *testing.T) {
+	log := NewMemLog[string](10)
+	log.Append("ERROR one")
+	log.Append("ERROR two")
+
+	w := &failAfterWriter{failAt: 1}
+	err := DumpColor(w, log, WithColor(true))
+
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 1, w.writes)
+}
@@ -0,0 +1,17 @@
+package memlog
+
+// Concat returns a new MemLog with capacity size holding the
+// entries of a followed by the entries of b, each ordered from
+// oldest to newest.  If there are more than size entries in
+// total the oldest are evicted, as if they had been appended
+// to the new log in that order.  The new log is independent
+// of a and b.
+func Concat[T any](a, b *MemLog[T], size int) *MemLog[T] {
+	log := NewMemLog[T](size)
+	for _, src := range []*MemLog[T]{a, b} {
+		for _, item := range src.Slice() {
+			log.Append(item)
+		}
+	}
+	return log
+}
@@ -0,0 +1,85 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_concat_larger_than_total(t *testing.T) {
+	// given two logs with entries
+	a := NewMemLog(5, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(5, WithInitialEntries([]int{10, 20, 30}))
+
+	// when they are concatenated into a larger log
+	log := Concat(a, b, 10)
+
+	// then every entry of a is followed by every entry of b
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, log.Slice())
+	assert.Equal(t, 10, log.Cap())
+}
+
+func Test_concat_equal_to_total(t *testing.T) {
+	// given two logs with entries
+	a := NewMemLog(5, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(5, WithInitialEntries([]int{10, 20, 30}))
+
+	// when they are concatenated into a log that exactly fits them
+	log := Concat(a, b, 6)
+
+	// then every entry is kept
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, log.Slice())
+	assert.Equal(t, 6, log.Cap())
+}
+
+func Test_concat_smaller_than_total(t *testing.T) {
+	// given two logs with entries
+	a := NewMemLog(5, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(5, WithInitialEntries([]int{10, 20, 30}))
+
+	// when they are concatenated into a smaller log
+	log := Concat(a, b, 4)
+
+	// then the oldest entries of a are dropped
+	assert.Equal(t, []int{3, 10, 20, 30}, log.Slice())
+	assert.Equal(t, 4, log.Cap())
+}
+
+func Test_concat_smaller_than_b(t *testing.T) {
+	// given two logs with entries
+	a := NewMemLog(5, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(5, WithInitialEntries([]int{10, 20, 30}))
+
+	// when they are concatenated into a log smaller than b
+	log := Concat(a, b, 2)
+
+	// then only the newest entries of b are kept
+	assert.Equal(t, []int{20, 30}, log.Slice())
+	assert.Equal(t, 2, log.Cap())
+}
+
+func Test_concat_does_not_modify_sources(t *testing.T) {
+	// given two logs with entries
+	a := NewMemLog(5, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(5, WithInitialEntries([]int{10, 20, 30}))
+
+	// when they are concatenated
+	Concat(a, b, 4)
+
+	// then the sources are unchanged
+	assert.Equal(t, []int{1, 2, 3}, a.Slice())
+	assert.Equal(t, []int{10, 20, 30}, b.Slice())
+}
+
+func Test_concat_empty(t *testing.T) {
+	// given two empty logs
+	a := NewMemLog[int](5)
+	b := NewMemLog[int](5)
+
+	// when they are concatenated
+	log := Concat(a, b, 5)
+
+	// then the result is empty
+	assert.Equal(t, 0, log.Len())
+	assert.Empty(t, log.Slice())
+}
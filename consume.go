@@ -0,0 +1,25 @@
+package memlog
+
+import "context"
+
+// ConsumeFrom appends each value received from ch to log until
+// ch is closed or ctx is done, and then returns ctx.Err().  It
+// returns nil if ch was closed before ctx was done.
+//
+// ConsumeFrom blocks while it runs, so it is usually started in
+// its own goroutine to bridge a channel based producer with a
+// MemLog.  Values still buffered in ch when ctx is done are not
+// appended.
+func ConsumeFrom[T any](ctx context.Context, log *MemLog[T], ch <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-ch:
+			if !ok {
+				return ctx.Err()
+			}
+			log.Append(item)
+		}
+	}
+}
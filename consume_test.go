@@ -0,0 +1,65 @@
+package memlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_consume_from_until_closed(t *testing.T) {
+	// given a producer writing to a buffered channel
+	log := NewMemLog[int](10)
+	ch := make(chan int, 4)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 6; i++ {
+			ch <- i
+		}
+	}()
+
+	// when the channel is consumed until it is closed
+	err := ConsumeFrom(context.Background(), log, ch)
+
+	// then every value is appended in order
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, log.Slice())
+}
+
+func Test_consume_from_cancelled(t *testing.T) {
+	// given a consumer reading from a channel that is never closed
+	log := NewMemLog[int](10)
+	ch := make(chan int, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		done <- ConsumeFrom(ctx, log, ch)
+	}()
+
+	ch <- 1
+	ch <- 2
+	assert.Eventually(t, func() bool { return log.Len() == 2 }, time.Second, time.Millisecond)
+
+	// when the context is cancelled
+	cancel()
+
+	// then ConsumeFrom returns the context's error
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("ConsumeFrom did not return")
+	}
+	assert.Equal(t, []int{1, 2}, log.Slice())
+}
+
+func Test_consume_from_already_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ConsumeFrom(ctx, NewMemLog[int](10), make(chan int))
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,109 @@
+package memlog
+
+import "sort"
+
+// Cursor reads the entries of a MemLog incrementally.  Each
+// cursor tracks its own position by sequence number so several
+// consumers can read the same log at their own pace without
+// copying it.
+//
+// When entries the cursor has not yet read are evicted or
+// otherwise removed from the log the cursor moves past them
+// and counts them as skipped.
+//
+// Cursor is thread-safe
+type Cursor[T any] struct {
+	log     *MemLog[T]
+	next    uint64
+	skipped uint64
+}
+
+// NewCursor returns a Cursor positioned at the
+// oldest entry currently in the log.
+func (m *MemLog[T]) NewCursor() *Cursor[T] {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	c := &Cursor[T]{log: m, next: m.seq}
+	if oldest := m.unread(0, 1); len(oldest) > 0 {
+		c.next = oldest[0].seq
+	}
+	return c
+}
+
+// Next returns the next unread entry and true, or
+// false if the cursor has read every entry in the log.
+func (c *Cursor[T]) Next() (item T, ok bool) {
+	batch := c.NextBatch(1)
+	if len(batch) == 0 {
+		return item, false
+	}
+	return batch[0], true
+}
+
+// NextBatch returns up to max unread entries,
+// ordered from oldest to newest.
+func (c *Cursor[T]) NextBatch(max int) []T {
+	m := c.log
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	if max <= 0 {
+		return nil
+	}
+
+	var batch []T
+	for _, entry := range m.unread(c.next, max) {
+		c.skipped += entry.seq - c.next
+		c.next = entry.seq + 1
+		batch = append(batch, entry.value)
+	}
+
+	if len(batch) < max {
+		// every remaining entry has been read or removed
+		c.skipped += m.seq - c.next
+		c.next = m.seq
+	}
+
+	return batch
+}
+
+// Skipped returns the number of entries that were
+// removed from the log before the cursor read them.
+func (c *Cursor[T]) Skipped() uint64 {
+	c.log.locker.Lock()
+	defer c.log.locker.Unlock()
+	return c.skipped
+}
+
+// unread returns up to max entries with a sequence number of at
+// least next, ordered by sequence number.  The caller must hold
+// the lock.
+func (m *MemLog[T]) unread(next uint64, max int) []*sequenced[T] {
+	var entries []*sequenced[T]
+
+	if m.order != nil {
+		// a sorted log is not ordered by sequence number
+		// so every entry must be checked
+		for i := 0; i < m.entries.len(); i++ {
+			if entry := m.entries.at(i); entry.seq >= next {
+				entries = append(entries, entry)
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].seq < entries[j].seq
+		})
+		return entries[:min(max, len(entries))]
+	}
+
+	// entries are ordered by sequence number so the oldest
+	// unread entry can be found with a binary search
+	first := sort.Search(m.entries.len(), func(i int) bool {
+		return m.entries.at(i).seq >= next
+	})
+	for i := first; i < m.entries.len() && len(entries) < max; i++ {
+		entries = append(entries, m.entries.at(i))
+	}
+
+	return entries
+}
@@ -0,0 +1,158 @@
+package memlog
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_cursor_reads_incrementally(t *testing.T) {
+	// given a log with existing entries and a cursor
+	log := NewMemLog[int](10)
+	log.Append(1)
+	log.Append(2)
+	c := log.NewCursor()
+
+	// when entries are read and more are appended
+	first, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 1, first)
+
+	log.Append(3)
+
+	// then only unread entries are returned
+	assert.Equal(t, []int{2, 3}, c.NextBatch(10))
+	_, ok = c.Next()
+	assert.False(t, ok)
+	assert.Empty(t, c.NextBatch(10))
+	assert.Zero(t, c.Skipped())
+}
+
+func Test_cursor_reports_skipped_entries(t *testing.T) {
+	// given a cursor that has fallen behind
+	log := NewMemLog[int](3)
+	c := log.NewCursor()
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	// when the remaining entries are read
+	batch := c.NextBatch(2)
+
+	// then evicted entries are counted as skipped
+	assert.Equal(t, []int{3, 4}, batch)
+	assert.Equal(t, uint64(2), c.Skipped())
+
+	log.Clear()
+	log.Append(6)
+	assert.Equal(t, []int{6}, c.NextBatch(10))
+	assert.Equal(t, uint64(3), c.Skipped())
+}
+
+func Test_cursor_independent_consumers(t *testing.T) {
+	// given a small log and a producer that wraps it many times
+	const total = 5000
+	log := NewMemLog[int](16)
+	fast := log.NewCursor()
+	slow := log.NewCursor()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 0; i < total; i++ {
+			log.Append(i)
+		}
+	}()
+
+	// when two cursors consume at different speeds
+	consume := func(c *Cursor[int], batch int) []int {
+		var seen []int
+		for {
+			select {
+			case <-done:
+				return append(seen, c.NextBatch(total)...)
+			default:
+				seen = append(seen, c.NextBatch(batch)...)
+			}
+		}
+	}
+
+	var fastSeen, slowSeen []int
+	wg.Add(2)
+	go func() { defer wg.Done(); fastSeen = consume(fast, total) }()
+	go func() { defer wg.Done(); slowSeen = consume(slow, 1) }()
+	wg.Wait()
+
+	// then each sees entries in order and accounts for every entry
+	for _, tt := range []struct {
+		c    *Cursor[int]
+		seen []int
+	}{{fast, fastSeen}, {slow, slowSeen}} {
+		assert.Equal(t, total, len(tt.seen)+int(tt.c.Skipped()))
+		for i := 1; i < len(tt.seen); i++ {
+			assert.Less(t, tt.seen[i-1], tt.seen[i])
+		}
+		assert.Equal(t, total-1, tt.seen[len(tt.seen)-1])
+	}
+}
+
+func Test_cursor_after_remove_if(t *testing.T) {
+	// given a cursor and entries removed from the middle of the log
+	log := NewMemLog[int](10)
+	c := log.NewCursor()
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+	log.RemoveIf(func(i int) bool { return i == 2 || i == 4 })
+
+	// when the cursor reads the log
+	batch := c.NextBatch(10)
+
+	// then the remaining entries are returned and removed ones skipped
+	assert.Equal(t, []int{1, 3, 5}, batch)
+	assert.Equal(t, uint64(2), c.Skipped())
+}
+
+func Test_cursor_over_sorted_log(t *testing.T) {
+	// given a cursor over a sorted log
+	log := NewSortedLog(10, func(a, b int) bool { return a < b })
+	c := log.NewCursor()
+
+	// when entries are appended out of order
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		log.Append(v)
+	}
+
+	// then they are read in the order they were appended
+	assert.Equal(t, []int{5, 1}, c.NextBatch(2))
+	assert.Equal(t, []int{9, 3, 7}, c.NextBatch(10))
+	assert.Equal(t, uint64(0), c.Skipped())
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, log.Slice())
+
+	// and an entry inserted before those already read is still read
+	log.Append(2)
+	assert.Equal(t, []int{2}, c.NextBatch(10))
+	assert.Empty(t, c.NextBatch(10))
+}
+
+func Test_cursor_over_full_sorted_log(t *testing.T) {
+	// given a cursor created on a full sorted log
+	log := NewSortedLog(3, func(a, b int) bool { return a < b })
+	log.Append(5)
+	log.Append(1)
+	log.Append(9)
+	c := log.NewCursor()
+
+	// when entries are evicted and discarded
+	log.Append(3)
+	log.Append(0)
+	log.Append(7)
+
+	// then only the retained entries are read, in append order
+	assert.Equal(t, []int{5, 9, 7}, c.NextBatch(10))
+	assert.Equal(t, uint64(3), c.Skipped())
+}
@@ -0,0 +1,35 @@
+package memlog
+
+// NewDebouncingLog returns a MemLog that only stores an
+// appended entry if it differs from the most recently stored
+// entry.  This collapses runs of identical entries, such as
+// repeated "health check OK" messages, into a single entry.
+//
+// The number of entries in each run is not tracked.  Entries
+// that are not stored are not counted in Stats and are not
+// delivered to subscribers, but do update Age.
+func NewDebouncingLog[T comparable](size int, opts ...Option[T]) *MemLog[T] {
+	opts = append(opts, WithCoalesce(func(a, b T) bool {
+		return a == b
+	}))
+	return NewMemLog(size, opts...)
+}
+
+// WithCoalesce causes an appended entry that is equal to the
+// most recently stored entry, according to equal, to be
+// dropped rather than stored.  See NewDebouncingLog.
+func WithCoalesce[T any](equal func(a, b T) bool) Option[T] {
+	return withMerge(func(last, item T) (T, bool) {
+		return last, equal(last, item)
+	})
+}
+
+// withMerge causes merge to be called with the most recently
+// stored entry and each appended item.  When merge returns true
+// the stored entry is replaced with the merged value instead
+// of item being appended.
+func withMerge[T any](merge func(last, item T) (T, bool)) Option[T] {
+	return func(m *MemLog[T]) {
+		m.merge = merge
+	}
+}
@@ -0,0 +1,30 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_debouncing_log_collapses_runs(t *testing.T) {
+	// given a debouncing log
+	log := NewDebouncingLog[string](10)
+
+	// when runs of identical entries are appended
+	for _, line := range []string{"ok", "ok", "ok", "fail", "fail", "ok", "ok"} {
+		log.Append(line)
+	}
+
+	// then each run is stored once
+	assert.Equal(t, []string{"ok", "fail", "ok"}, log.Slice())
+	assert.Equal(t, uint64(3), log.Stats().TotalAppends)
+}
+
+func Test_debouncing_log_after_clear(t *testing.T) {
+	log := NewDebouncingLog[int](10)
+	log.Append(1)
+	log.Clear()
+	log.Append(1)
+
+	assert.Equal(t, []int{1}, log.Slice())
+}
@@ -0,0 +1,18 @@
+package memlog
+
+// NewDeduplicatingLog returns a new log that holds each value
+// at most once.  Appending a value that is already in the log
+// moves it to the newest position instead of adding a
+// duplicate.  When the log is full the oldest value is removed
+// to make room for a new one.
+//
+// The log is an LRULog rather than a MemLog: a MemLog keeps its
+// entries in a ring, from which a repeated value can only be
+// moved by shifting the entries after it.  An LRULog keeps
+// values in a list with a map from each value to its element,
+// so Append runs in constant time whether or not the value is
+// already present, and it provides the Slice, SliceN, Len, Cap,
+// Stats and Clear methods of a MemLog.
+func NewDeduplicatingLog[T comparable](size int) *LRULog[T] {
+	return NewLRULog[T](size)
+}
@@ -0,0 +1,83 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_deduplicating_log_moves_duplicates_to_back(t *testing.T) {
+	// given a deduplicating log with several values
+	log := NewDeduplicatingLog[string](5)
+	log.Append("a")
+	log.Append("b")
+	log.Append("c")
+
+	// when existing values are appended again
+	log.Append("a")
+	log.Append("c")
+
+	// then each value is held once at its newest position
+	assert.Equal(t, []string{"b", "a", "c"}, log.Slice())
+	assert.Equal(t, 3, log.Len())
+}
+
+func Test_deduplicating_log_evicts_oldest(t *testing.T) {
+	// given a full deduplicating log
+	log := NewDeduplicatingLog[int](3)
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+	log.Append(1)
+
+	// when a new value is appended
+	log.Append(4)
+
+	// then the oldest value is evicted and can be added again
+	assert.Equal(t, []int{3, 1, 4}, log.Slice())
+
+	log.Append(2)
+	assert.Equal(t, []int{1, 4, 2}, log.Slice())
+}
+
+func Test_deduplicating_log_many_duplicates(t *testing.T) {
+	// given a log that has seen each value many times
+	log := NewDeduplicatingLog[int](4)
+	for i := 0; i < 10; i++ {
+		log.Append(i % 6)
+	}
+
+	// when values are appended again
+	log.Append(2)
+	log.Append(0)
+
+	// then each value is held once, most recent last
+	assert.Equal(t, []int{1, 3, 2, 0}, log.Slice())
+}
+
+func Test_deduplicating_log_memlog_methods(t *testing.T) {
+	// given a deduplicating log that has evicted a value
+	log := NewDeduplicatingLog[string](3)
+	for _, v := range []string{"a", "b", "a", "c", "d"} {
+		log.Append(v)
+	}
+
+	// when it is read like a MemLog
+	// then the newest values and the counters are reported
+	assert.Equal(t, []string{"c", "d"}, log.SliceN(2))
+	assert.Equal(t, 3, log.Cap())
+	assert.Equal(t, uint64(5), log.Stats().TotalAppends)
+	assert.Equal(t, uint64(1), log.Stats().TotalEvictions)
+}
+
+func Benchmark_deduplicating_log_append_duplicate(b *testing.B) {
+	log := NewDeduplicatingLog[int](10000)
+	for i := 0; i < 10000; i++ {
+		log.Append(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Append(i % 10000)
+	}
+}
@@ -0,0 +1,85 @@
+package memlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// DefaultSize is the number of entries held by the default
+// log unless SetDefaultSize is called before it is used.
+const DefaultSize = 1000
+
+// ErrDefaultInUse is returned by SetDefaultSize once the
+// default log has been created.
+var ErrDefaultInUse = errors.New("memlog: default log already in use")
+
+// defaultLog holds the package-level StringLog used by
+// Append, Printf, Slice, SliceN and Writer.
+type defaultLog struct {
+	once    sync.Once
+	log     *StringLog
+	size    int
+	created bool
+	locker  sync.Mutex
+}
+
+// std is the default log.  It is created on first use.
+var std = &defaultLog{size: DefaultSize}
+
+// get returns the default log, creating it if necessary.
+func (d *defaultLog) get() *StringLog {
+	d.once.Do(func() {
+		d.locker.Lock()
+		defer d.locker.Unlock()
+		d.created = true
+		d.log = NewStringLog(d.size)
+	})
+	return d.log
+}
+
+// SetDefaultSize sets the number of entries held by the
+// default log.  It returns ErrDefaultInUse if the default
+// log has already been used.
+func SetDefaultSize(n int) error {
+	std.locker.Lock()
+	defer std.locker.Unlock()
+
+	if std.created {
+		return ErrDefaultInUse
+	}
+	std.size = n
+	return nil
+}
+
+// Append adds line to the default log as a single entry.
+func Append(line string) {
+	std.get().Buffer.Append(line)
+}
+
+// Printf formats according to a format specifier and adds
+// the result to the default log as a single entry.  A
+// trailing newline is removed.
+func Printf(format string, args ...any) {
+	Append(strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
+
+// Slice returns the entries in the default log ordered
+// from oldest to newest.
+func Slice() []string {
+	return std.get().Buffer.Slice()
+}
+
+// SliceN returns the newest n entries in the default log
+// ordered from oldest to newest.
+func SliceN(n int) []string {
+	return std.get().Buffer.SliceN(n)
+}
+
+// Writer returns an io.Writer that stores each line
+// written to it in the default log.
+func Writer() io.Writer {
+	return std.get()
+}
@@ -0,0 +1,77 @@
+package memlog
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resetDefaultLog replaces the default log with one
+// that has not been used.
+func resetDefaultLog() {
+	std = &defaultLog{size: DefaultSize}
+}
+
+func Test_default_log_set_size_before_use(t *testing.T) {
+	// given a default log sized before first use
+	resetDefaultLog()
+	defer resetDefaultLog()
+	assert.NoError(t, SetDefaultSize(3))
+
+	// when more entries than its size are added
+	for i := 0; i < 5; i++ {
+		Append(fmt.Sprint(i))
+	}
+
+	// then the log is bounded by the configured size
+	assert.Equal(t, []string{"2", "3", "4"}, Slice())
+	assert.Equal(t, []string{"4"}, SliceN(1))
+
+	// and the size can no longer be changed
+	assert.ErrorIs(t, SetDefaultSize(10), ErrDefaultInUse)
+}
+
+func Test_default_log_concurrent_first_use(t *testing.T) {
+	// given a default log that has not been used
+	resetDefaultLog()
+	defer resetDefaultLog()
+
+	// when many goroutines use it at once
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			Printf("entry %d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	// then a single log receives every entry
+	assert.Len(t, Slice(), 20)
+}
+
+func Test_default_log_printf_single_entry(t *testing.T) {
+	// given an unused default log
+	resetDefaultLog()
+	defer resetDefaultLog()
+
+	// when formatted entries are added
+	Printf("starting up: %s", "localhost:8080")
+	Printf("listening on %d\n", 8080)
+
+	// then each call is stored as one entry
+	assert.Equal(t, []string{"starting up: localhost:8080", "listening on 8080"}, Slice())
+}
+
+func Test_default_log_writer(t *testing.T) {
+	resetDefaultLog()
+	defer resetDefaultLog()
+
+	_, err := fmt.Fprintf(Writer(), "one\ntwo\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, Slice())
+}
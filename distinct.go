@@ -0,0 +1,73 @@
+package memlog
+
+// Distinct returns the entries in log with duplicates removed.
+// Each value appears once, in the position of its first
+// occurrence, ordered from oldest to newest.  The log is not
+// modified.
+//
+// Distinct is a function rather than a method because it
+// requires entries to be comparable.
+func Distinct[T comparable](log *MemLog[T]) []T {
+	seen := make(map[T]struct{})
+	var slice []T
+
+	for _, item := range log.Slice() {
+		if _, ok := seen[item]; !ok {
+			seen[item] = struct{}{}
+			slice = append(slice, item)
+		}
+	}
+
+	return slice
+}
+
+// DistinctFunc is like Distinct but uses eq to compare entries,
+// which allows it to be used with types that are not comparable.
+// It compares each entry with every distinct entry found so far
+// and so takes O(n²) time.
+func DistinctFunc[T any](log *MemLog[T], eq func(T, T) bool) []T {
+	var slice []T
+
+	for _, item := range log.Slice() {
+		if !containsFunc(slice, item, eq) {
+			slice = append(slice, item)
+		}
+	}
+
+	return slice
+}
+
+// containsFunc reports whether slice contains an
+// entry equal to item according to eq.
+func containsFunc[T any](slice []T, item T, eq func(T, T) bool) bool {
+	for _, v := range slice {
+		if eq(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendUnique adds item to log only if an equal entry is not
+// already present and reports whether item was added.  This is
+// useful for small logs such as a list of recently seen URLs.
+//
+// AppendUnique scans the entire log while holding its lock and
+// so takes O(n) time.
+func AppendUnique[T comparable](log *MemLog[T], item T) bool {
+	log.locker.Lock()
+
+	for i := 0; i < log.entries.len(); i++ {
+		if log.entries.at(i).value == item {
+			log.locker.Unlock()
+			return false
+		}
+	}
+
+	err, fireOnFull := log.appendLocked(item, log.reject, true)
+	evicted := log.takeEvicted()
+	log.locker.Unlock()
+
+	log.notify(item, err, evicted, fireOnFull)
+	return err == nil || err == ErrOverflow
+}
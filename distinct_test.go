@@ -0,0 +1,71 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_distinct(t *testing.T) {
+	// given a log with duplicate entries
+	log := NewMemLog[string](10)
+	for _, v := range []string{"b", "a", "b", "c", "a"} {
+		log.Append(v)
+	}
+
+	// when distinct entries are requested
+	distinct := Distinct(log)
+
+	// then each value appears once in order of first occurrence
+	assert.Equal(t, []string{"b", "a", "c"}, distinct)
+	assert.Equal(t, []string{"b", "a", "b", "c", "a"}, log.Slice())
+}
+
+func Test_distinct_empty(t *testing.T) {
+	assert.Empty(t, Distinct(NewMemLog[int](10)))
+}
+
+func Test_distinct_func(t *testing.T) {
+	// given a log of non-comparable entries
+	log := NewMemLog[[]int](10)
+	log.Append([]int{1, 2})
+	log.Append([]int{3})
+	log.Append([]int{1, 2})
+
+	// when distinct entries are requested using an equality func
+	distinct := DistinctFunc(log, func(a, b []int) bool {
+		return assert.ObjectsAreEqual(a, b)
+	})
+
+	// then duplicates are removed
+	assert.Equal(t, [][]int{{1, 2}, {3}}, distinct)
+	assert.Equal(t, 3, log.Len())
+}
+
+func Test_append_unique(t *testing.T) {
+	// given a log of seen names
+	log := NewMemLog[string](10)
+
+	// when names are appended uniquely
+	assert.True(t, AppendUnique(log, "api"))
+	assert.True(t, AppendUnique(log, "db"))
+	assert.False(t, AppendUnique(log, "api"))
+
+	// then duplicates are not stored
+	assert.Equal(t, []string{"api", "db"}, log.Slice())
+
+	// and may be added again after the log is cleared
+	log.Clear()
+	assert.True(t, AppendUnique(log, "api"))
+	assert.Equal(t, []string{"api"}, log.Slice())
+}
+
+func Test_append_unique_after_eviction(t *testing.T) {
+	log := NewMemLog[int](2)
+	AppendUnique(log, 1)
+	AppendUnique(log, 2)
+	AppendUnique(log, 3)
+
+	assert.True(t, AppendUnique(log, 1))
+	assert.Equal(t, []int{3, 1}, log.Slice())
+}
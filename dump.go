@@ -0,0 +1,51 @@
+package memlog
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Dump writes the contents of the log to w, oldest to newest,
+// one entry per line.  Each entry is formatted using format or,
+// if format is nil, using the %v verb.  Dump stops at and
+// returns the first write error.
+//
+// Entries are written one at a time from a snapshot of the log
+// so the log is not locked while writing to w.
+func (m *MemLog[T]) Dump(w io.Writer, format func(T) string) error {
+	return m.dump(w, format, false)
+}
+
+// DumpIndexed is like Dump but prefixes each line with the
+// position of the entry in the log, starting at 0 for the
+// oldest entry.
+func (m *MemLog[T]) DumpIndexed(w io.Writer, format func(T) string) error {
+	return m.dump(w, format, true)
+}
+
+// dump writes each entry in the log to w.
+func (m *MemLog[T]) dump(w io.Writer, format func(T) string, indexed bool) error {
+	if format == nil {
+		format = func(item T) string {
+			return fmt.Sprintf("%v", item)
+		}
+	}
+
+	var line []byte
+	for i, item := range m.Slice() {
+		line = line[:0]
+		if indexed {
+			line = strconv.AppendInt(line, int64(i), 10)
+			line = append(line, ": "...)
+		}
+		line = append(line, format(item)...)
+		line = append(line, '\n')
+
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
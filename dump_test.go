@@ -0,0 +1,79 @@
+package memlog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failAfterWriter struct {
+	writes int
+	failAt int
+}
+
+func (f *failAfterWriter) Write(p []byte) (int, error) {
+	f.writes++
+	if f.writes >= f.failAt {
+		return 0, errors.New("write failed")
+	}
+	return len(p), nil
+}
+
+func Test_memlog_dump_default_format(t *testing.T) {
+	// given a log of structs
+	type point struct{ X, Y int }
+	log := NewMemLog[point](2)
+	log.Append(point{1, 2})
+	log.Append(point{3, 4})
+	log.Append(point{5, 6})
+
+	// when dumped without a format func
+	var buf bytes.Buffer
+	err := log.Dump(&buf, nil)
+
+	// then each entry is written using %v
+	assert.NoError(t, err)
+	assert.Equal(t, "{3 4}\n{5 6}\n", buf.String())
+}
+
+func Test_memlog_dump_custom_format(t *testing.T) {
+	type point struct{ X, Y int }
+	log := NewMemLog[point](5)
+	log.Append(point{1, 2})
+	log.Append(point{3, 4})
+
+	var buf bytes.Buffer
+	err := log.DumpIndexed(&buf, func(p point) string {
+		return fmt.Sprintf("x=%d y=%d", p.X, p.Y)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0: x=1 y=2\n1: x=3 y=4\n", buf.String())
+}
+
+func Test_memlog_dump_empty(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewMemLog[int](5).Dump(&buf, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func Test_memlog_dump_write_error(t *testing.T) {
+	// given a writer that fails on the second write
+	log := NewMemLog[int](5)
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+	w := &failAfterWriter{failAt: 2}
+
+	// when the log is dumped
+	err := log.Dump(w, nil)
+
+	// then the first error is returned and writing stops
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 2, w.writes)
+}
@@ -0,0 +1,105 @@
+package memlog
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// maxStackDepth is the maximum number of frames
+// recorded in the stack of an ErrEntry.
+const maxStackDepth = 32
+
+// ErrEntry is an error recorded by an ErrorLog.
+type ErrEntry struct {
+	Time  time.Time
+	Err   error
+	Msg   string
+	Stack []byte
+}
+
+// ErrorLog is a bounded history of errors, each recorded
+// with the time it was captured and the stack of the code
+// that captured it.
+//
+// ErrorLog is thread-safe
+type ErrorLog struct {
+	Buffer *MemLog[ErrEntry]
+}
+
+// NewErrorLog returns a new ErrorLog that will not grow
+// beyond size entries.
+func NewErrorLog(size int, opts ...Option[ErrEntry]) *ErrorLog {
+	return &ErrorLog{
+		Buffer: NewMemLog(size, opts...),
+	}
+}
+
+// Capture records err along with a message formatted
+// according to msgf.  A nil err is ignored.
+func (l *ErrorLog) Capture(err error, msgf string, args ...any) {
+	if err == nil {
+		return
+	}
+	l.capture(err, fmt.Sprintf(msgf, args...))
+}
+
+// Wrap records err and returns it unchanged, which allows
+// errors to be recorded as they are returned:
+//
+//	return errs.Wrap(err)
+//
+// A nil err is ignored.
+func (l *ErrorLog) Wrap(err error) error {
+	if err != nil {
+		l.capture(err, "")
+	}
+	return err
+}
+
+// capture adds an entry for err.  It must be called directly
+// by Capture or Wrap so that the recorded stack starts at
+// their caller.
+func (l *ErrorLog) capture(err error, msg string) {
+	l.Buffer.Append(ErrEntry{
+		Time: l.Buffer.now(),
+		Err:  err,
+		Msg:  msg,
+		// skip capture and Capture or Wrap
+		Stack: callerStack(2),
+	})
+}
+
+// Slice returns the recorded errors ordered
+// from oldest to newest.
+func (l *ErrorLog) Slice() []ErrEntry {
+	return l.Buffer.Slice()
+}
+
+// callerStack formats up to maxStackDepth frames of the stack,
+// starting skip frames above the caller of callerStack, as
+// function names followed by their file and line.
+func callerStack(skip int) []byte {
+	var pcs [maxStackDepth]uintptr
+
+	// skip runtime.Callers and callerStack
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []byte
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			stack = append(stack, frame.Function...)
+			stack = append(stack, "\n\t"...)
+			stack = append(stack, frame.File...)
+			stack = append(stack, ':')
+			stack = strconv.AppendInt(stack, int64(frame.Line), 10)
+			stack = append(stack, '\n')
+		}
+		if !more {
+			return stack
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package memlog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_error_log_capture(t *testing.T) {
+	// given an error log with a fake clock
+	clock := NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	log := NewErrorLog(10, WithClock[ErrEntry](clock))
+	err := errors.New("connection refused")
+
+	// when an error is captured
+	log.Capture(err, "dial %s", "db:5432")
+
+	// then it is recorded with its time, message and stack
+	entries := log.Slice()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, clock.Now(), entries[0].Time)
+	assert.Same(t, err, entries[0].Err)
+	assert.Equal(t, "dial db:5432", entries[0].Msg)
+
+	stack := string(entries[0].Stack)
+	assert.True(t, strings.HasPrefix(stack, "github.com/yabosh/memlog.Test_error_log_capture\n"), stack)
+	assert.Contains(t, stack, "error_log_test.go:")
+	assert.NotContains(t, stack, "callerStack")
+}
+
+func openConfig(log *ErrorLog) error {
+	return log.Wrap(errors.New("not found"))
+}
+
+func Test_error_log_wrap(t *testing.T) {
+	// given an error log
+	log := NewErrorLog(10)
+
+	// when an error is wrapped as it is returned
+	err := openConfig(log)
+
+	// then the error is returned unchanged and recorded
+	assert.EqualError(t, err, "not found")
+	entries := log.Slice()
+	assert.Len(t, entries, 1)
+	assert.Same(t, err, entries[0].Err)
+	assert.True(t, strings.HasPrefix(string(entries[0].Stack), "github.com/yabosh/memlog.openConfig\n"))
+}
+
+func Test_error_log_ignores_nil(t *testing.T) {
+	log := NewErrorLog(10)
+
+	log.Capture(nil, "nothing happened")
+	assert.NoError(t, log.Wrap(nil))
+
+	assert.Empty(t, log.Slice())
+}
+
+func Test_error_log_bounded(t *testing.T) {
+	// given a small error log
+	log := NewErrorLog(100)
+
+	// when it is flooded with errors
+	for i := 0; i < 10000; i++ {
+		log.Capture(fmt.Errorf("error %d", i), "flood")
+	}
+
+	// then only the newest errors are kept
+	entries := log.Slice()
+	assert.Len(t, entries, 100)
+	assert.EqualError(t, entries[0].Err, "error 9900")
+	assert.EqualError(t, entries[99].Err, "error 9999")
+}
@@ -0,0 +1,24 @@
+package memlog
+
+// FrequencyMap returns the number of times each distinct
+// value occurs in log.
+func FrequencyMap[T comparable](log *MemLog[T]) map[T]int {
+	return FrequencyMapFunc(log, func(item T) T {
+		return item
+	})
+}
+
+// FrequencyMapFunc returns the number of entries in log with
+// each key returned by key.  This allows entries that are not
+// comparable to be counted by a comparable property.
+func FrequencyMapFunc[T any, K comparable](log *MemLog[T], key func(T) K) map[K]int {
+	log.rlock()
+	defer log.runlock()
+
+	counts := make(map[K]int)
+	log.forEachN(allElements, func(item T) {
+		counts[key(item)]++
+	})
+
+	return counts
+}
@@ -0,0 +1,45 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_frequency_map(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		want  map[string]int
+	}{
+		{"all unique", []string{"a", "b", "c"}, map[string]int{"a": 1, "b": 1, "c": 1}},
+		{"all same", []string{"a", "a", "a"}, map[string]int{"a": 3}},
+		{"mixed", []string{"a", "b", "a"}, map[string]int{"a": 2, "b": 1}},
+		{"empty", nil, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := NewMemLog[string](10)
+			for _, item := range tt.items {
+				log.Append(item)
+			}
+
+			assert.Equal(t, tt.want, FrequencyMap(log))
+		})
+	}
+}
+
+func Test_frequency_map_func(t *testing.T) {
+	// given a log of non-comparable entries
+	log := NewMemLog[[]string](10)
+	log.Append([]string{"GET", "/a"})
+	log.Append([]string{"POST", "/a"})
+	log.Append([]string{"GET", "/b"})
+
+	// when counted by method
+	counts := FrequencyMapFunc(log, func(req []string) string { return req[0] })
+
+	// then each key is counted
+	assert.Equal(t, map[string]int{"GET": 2, "POST": 1}, counts)
+}
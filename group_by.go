@@ -0,0 +1,18 @@
+package memlog
+
+// GroupBy returns the entries in log grouped by the key
+// returned by key, for instance to group entries by severity
+// or service name.  The entries in each group are ordered
+// from oldest item to the newest.
+func GroupBy[T any, K comparable](log *MemLog[T], key func(T) K) map[K][]T {
+	log.rlock()
+	defer log.runlock()
+
+	groups := make(map[K][]T)
+	log.forEachN(allElements, func(item T) {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	})
+
+	return groups
+}
@@ -0,0 +1,41 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_group_by(t *testing.T) {
+	// given a log of entries at several levels
+	log := NewMemLog[LevelEntry[string]](10)
+	log.Append(LevelEntry[string]{LevelInfo, "i1"})
+	log.Append(LevelEntry[string]{LevelError, "e1"})
+	log.Append(LevelEntry[string]{LevelInfo, "i2"})
+	log.Append(LevelEntry[string]{LevelError, "e2"})
+
+	// when grouped by level
+	groups := GroupBy(log, func(e LevelEntry[string]) Level { return e.Level })
+
+	// then each group keeps its entries in order
+	assert.Equal(t, map[Level][]LevelEntry[string]{
+		LevelInfo:  {{LevelInfo, "i1"}, {LevelInfo, "i2"}},
+		LevelError: {{LevelError, "e1"}, {LevelError, "e2"}},
+	}, groups)
+}
+
+func Test_group_by_single_group(t *testing.T) {
+	log := NewMemLog[int](10)
+	log.Append(1)
+	log.Append(2)
+
+	groups := GroupBy(log, func(int) string { return "all" })
+
+	assert.Equal(t, map[string][]int{"all": {1, 2}}, groups)
+}
+
+func Test_group_by_empty(t *testing.T) {
+	groups := GroupBy(NewMemLog[int](10), func(i int) int { return i })
+
+	assert.Empty(t, groups)
+}
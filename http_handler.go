@@ -0,0 +1,43 @@
+package memlog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// httpHandler serves the contents of a MemLog as JSON.
+type httpHandler[T any] struct {
+	log *MemLog[T]
+}
+
+// NewHTTPHandler returns an http.Handler that responds to GET
+// requests with the current contents of log as a JSON array
+// ordered from oldest item to the newest.  The optional query
+// parameter n limits the response to the last n entries.
+func NewHTTPHandler[T any](log *MemLog[T]) http.Handler {
+	return &httpHandler[T]{log: log}
+}
+
+// ServeHTTP writes the log contents as JSON.
+func (h *httpHandler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	n := allElements
+	if param := r.URL.Query().Get("n"); param != "" {
+		var err error
+		n, err = strconv.Atoi(param)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid value for n", http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(h.log.SliceN(n))
+}
@@ -0,0 +1,100 @@
+package memlog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_http_handler_returns_entries(t *testing.T) {
+	// given a log with entries
+	log := NewMemLog[string](10)
+	log.Append("item #1")
+	log.Append("item #2")
+	log.Append("item #3")
+
+	// when the handler is called
+	rec := httptest.NewRecorder()
+	NewHTTPHandler(log).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs", nil))
+
+	// then the entries are returned as json
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `["item #1","item #2","item #3"]`, rec.Body.String())
+}
+
+func Test_http_handler_last_n(t *testing.T) {
+	log := NewMemLog[int](10)
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+	}
+
+	tests := []struct {
+		query string
+		code  int
+		body  string
+	}{
+		{"?n=2", http.StatusOK, `[3,4]`},
+		{"?n=0", http.StatusOK, `[]`},
+		{"?n=50", http.StatusOK, `[0,1,2,3,4]`},
+		{"?n=abc", http.StatusBadRequest, ""},
+		{"?n=-1", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewHTTPHandler(log).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs"+tt.query, nil))
+
+			assert.Equal(t, tt.code, rec.Code)
+			if tt.body != "" {
+				assert.JSONEq(t, tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func Test_http_handler_empty_log(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHTTPHandler(NewMemLog[string](10)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs", nil))
+
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func Test_http_handler_rejects_other_methods(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHTTPHandler(NewMemLog[string](10)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/logs", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodGet, rec.Header().Get("Allow"))
+}
+
+func Test_http_handler_server(t *testing.T) {
+	// given a server for a log of structs
+	type result struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}
+	log := NewMemLog[result](10)
+	log.Append(result{ID: 1, Status: "ok"})
+	log.Append(result{ID: 2, Status: "failed"})
+
+	server := httptest.NewServer(NewHTTPHandler(log))
+	defer server.Close()
+
+	// when the log is requested
+	resp, err := http.Get(server.URL + "?n=1")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// then the last entry is returned
+	body, _ := io.ReadAll(resp.Body)
+	var got []result
+	assert.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, []result{{ID: 2, Status: "failed"}}, got)
+}
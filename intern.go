@@ -0,0 +1,61 @@
+package memlog
+
+// internTable maps strings to a canonical instance so that
+// duplicates share memory.  The table is bounded by keeping
+// two generations of entries: when the current generation
+// is full it replaces the previous one, discarding strings
+// that have not been seen recently.
+type internTable struct {
+	current  map[string]string
+	previous map[string]string
+	capacity int
+}
+
+// intern returns the canonical instance of s
+// and whether it was already in the table.
+func (t *internTable) intern(s string) (string, bool) {
+	if canonical, ok := t.current[s]; ok {
+		return canonical, true
+	}
+
+	canonical, ok := t.previous[s]
+	if !ok {
+		canonical = s
+	}
+
+	if len(t.current) >= t.capacity {
+		t.previous = t.current
+		t.current = make(map[string]string, t.capacity)
+	}
+	t.current[canonical] = canonical
+
+	return canonical, ok
+}
+
+// WithInterning causes each string appended to the log to be
+// replaced with a canonical instance of an equal string that
+// was recently appended, so that duplicate entries share
+// memory.  This reduces memory use when a log is dominated by
+// a small number of distinct, frequently repeated messages.
+//
+// The intern table holds between capacity and twice capacity
+// distinct strings, discarding those that have not been seen
+// recently.  Hits and misses are counted in Stats.
+func WithInterning(capacity int) Option[string] {
+	return func(m *MemLog[string]) {
+		table := &internTable{
+			current:  make(map[string]string, capacity),
+			capacity: capacity,
+		}
+
+		m.intern = func(s string) string {
+			canonical, hit := table.intern(s)
+			if hit {
+				m.stats.InternHits++
+			} else {
+				m.stats.InternMisses++
+			}
+			return canonical
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package memlog
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memlog_interning_is_transparent(t *testing.T) {
+	// given a log with interning
+	log := NewMemLog[string](10, WithInterning(4))
+
+	// when duplicate strings built separately are appended
+	for i := 0; i < 3; i++ {
+		log.Append(strings.Repeat("a", 3))
+		log.Append(fmt.Sprint("b", i))
+	}
+
+	// then the contents are unchanged and duplicates share memory
+	slice := log.Slice()
+	assert.Equal(t, []string{"aaa", "b0", "aaa", "b1", "aaa", "b2"}, slice)
+	assert.Equal(t, unsafe.StringData(slice[0]), unsafe.StringData(slice[2]))
+	assert.Equal(t, unsafe.StringData(slice[0]), unsafe.StringData(slice[4]))
+
+	stats := log.Stats()
+	assert.Equal(t, uint64(2), stats.InternHits)
+	assert.Equal(t, uint64(4), stats.InternMisses)
+}
+
+func Test_intern_table_is_bounded(t *testing.T) {
+	// given a small intern table
+	table := &internTable{current: map[string]string{}, capacity: 10}
+
+	// when many unique strings are interned
+	for i := 0; i < 1000; i++ {
+		table.intern(fmt.Sprint(i))
+	}
+
+	// then at most two generations are retained
+	assert.LessOrEqual(t, len(table.current)+len(table.previous), 20)
+
+	// and recently seen strings are still hits
+	_, hit := table.intern("999")
+	assert.True(t, hit)
+	_, hit = table.intern("0")
+	assert.False(t, hit)
+}
+
+func Test_string_log_interning(t *testing.T) {
+	sl := NewStringLog(10, WithStringInterning(16))
+	sl.Write([]byte("health check OK\nhealth check OK\n"))
+
+	slice := sl.Buffer.Slice()
+	assert.Equal(t, []string{"health check OK", "health check OK"}, slice)
+	assert.Equal(t, unsafe.StringData(slice[0]), unsafe.StringData(slice[1]))
+	assert.Equal(t, uint64(1), sl.Buffer.Stats().InternHits)
+}
+
+func benchmarkDuplicateHeavy(b *testing.B, opts ...Option[string]) {
+	messages := make([]string, 200)
+	for i := range messages {
+		messages[i] = fmt.Sprintf("request to /api/v1/resource/%d completed successfully", i)
+	}
+
+	var before, after runtime.MemStats
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		log := NewMemLog[string](50000, opts...)
+		for j := 0; j < 50000; j++ {
+			// copy the message as it would be when read from a writer
+			log.Append(string([]byte(messages[j%len(messages)])))
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc)), "retained-bytes")
+		runtime.KeepAlive(log)
+	}
+}
+
+func Benchmark_memlog_duplicate_heavy(b *testing.B) {
+	benchmarkDuplicateHeavy(b)
+}
+
+func Benchmark_memlog_duplicate_heavy_interned(b *testing.B) {
+	benchmarkDuplicateHeavy(b, WithInterning(1000))
+}
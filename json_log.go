@@ -0,0 +1,119 @@
+package memlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// rawKey is the key under which NewJSONLog stores
+// lines that are not valid JSON objects.
+const rawKey = "_raw"
+
+// errNotObject is returned by the NewJSONLog decoder
+// for valid JSON that is not an object, such as null.
+var errNotObject = errors.New("memlog: not a JSON object")
+
+// JSONLog is an io.Writer that parses each line written
+// to it as JSON and stores the decoded value in a MemLog.
+// Partial lines are buffered until a newline is received
+// and blank lines are ignored.
+//
+// JSONLog is thread-safe
+type JSONLog[T any] struct {
+	Buffer   *MemLog[T]
+	decode   func(line []byte) (T, error)
+	fallback func(line string) T
+	pending  bytes.Buffer
+	locker   sync.Mutex
+}
+
+// NewJSONLog returns a JSONLog that decodes each line into a
+// map and keeps at most size entries.  Lines that cannot be
+// decoded, or that hold a JSON value other than an object, are
+// stored as a map containing the original line under the
+// "_raw" key.
+func NewJSONLog(size int) *JSONLog[map[string]any] {
+	return NewJSONLogFunc(size,
+		func(line []byte) (map[string]any, error) {
+			var v map[string]any
+			err := json.Unmarshal(line, &v)
+			if err == nil && v == nil {
+				err = errNotObject
+			}
+			return v, err
+		},
+		func(line string) map[string]any {
+			return map[string]any{rawKey: line}
+		})
+}
+
+// NewJSONLogFunc returns a JSONLog that keeps at most size
+// entries, using decode to convert each line.  Lines that
+// decode fails on are passed to fallback and the result is
+// stored instead.  If fallback is nil these lines are dropped.
+func NewJSONLogFunc[T any](size int, decode func(line []byte) (T, error), fallback func(line string) T) *JSONLog[T] {
+	return &JSONLog[T]{
+		Buffer:   NewMemLog[T](size),
+		decode:   decode,
+		fallback: fallback,
+	}
+}
+
+// Write provides an implementation of the io.Writer
+// interface that decodes each complete line in p and
+// stores the result.
+func (j *JSONLog[T]) Write(p []byte) (n int, err error) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	for {
+		idx := bytes.IndexByte(p, '\n')
+		if idx < 0 {
+			j.pending.Write(p)
+			return n + len(p), nil
+		}
+
+		line := p[:idx]
+		if j.pending.Len() > 0 {
+			j.pending.Write(line)
+			line = j.pending.Bytes()
+		}
+		j.store(line)
+		j.pending.Reset()
+
+		n += idx + 1
+		p = p[idx+1:]
+	}
+}
+
+// Flush decodes and stores any buffered partial line.
+func (j *JSONLog[T]) Flush() error {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	j.store(j.pending.Bytes())
+	j.pending.Reset()
+	return nil
+}
+
+// store decodes line and appends the result to the buffer.
+func (j *JSONLog[T]) store(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+
+	// the decoder may keep line, which is only
+	// valid until the next call to Write
+	v, err := j.decode(bytes.Clone(line))
+	if err == nil {
+		j.Buffer.Append(v)
+		return
+	}
+
+	if j.fallback != nil {
+		j.Buffer.Append(j.fallback(string(line)))
+	}
+}
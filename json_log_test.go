@@ -0,0 +1,132 @@
+package memlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_json_log_parses_lines(t *testing.T) {
+	// given a json log
+	jl := NewJSONLog(10)
+
+	// when a multi-line write of json objects is made
+	jl.Write([]byte(`{"level":"info","msg":"started"}` + "\n" + `{"level":"error","code":500}` + "\r\n"))
+
+	// then each line is stored as a map
+	assert.Equal(t, []map[string]any{
+		{"level": "info", "msg": "started"},
+		{"level": "error", "code": float64(500)},
+	}, jl.Buffer.Slice())
+}
+
+func Test_json_log_stores_broken_json_as_raw(t *testing.T) {
+	jl := NewJSONLog(10)
+
+	jl.Write([]byte("{\"level\":\"info\"\nplain text\n[1,2]\n\n"))
+
+	assert.Equal(t, []map[string]any{
+		{"_raw": `{"level":"info"`},
+		{"_raw": "plain text"},
+		{"_raw": "[1,2]"},
+	}, jl.Buffer.Slice())
+}
+
+func Test_json_log_stores_non_objects_as_raw(t *testing.T) {
+	// given a json log
+	jl := NewJSONLog(10)
+
+	// when valid JSON values other than objects are written
+	jl.Write([]byte("null\n42\n\"text\"\n{}\n"))
+
+	// then they are stored as raw lines
+	assert.Equal(t, []map[string]any{
+		{"_raw": "null"},
+		{"_raw": "42"},
+		{"_raw": `"text"`},
+		{},
+	}, jl.Buffer.Slice())
+}
+
+func Test_json_log_buffers_partial_lines(t *testing.T) {
+	// given a json log
+	jl := NewJSONLog(10)
+
+	// when an object is split across writes
+	jl.Write([]byte(`{"msg":`))
+	jl.Write([]byte(`"hello"}` + "\n" + `{"msg":"tail"}`))
+
+	// then only complete lines are stored until flushed
+	assert.Equal(t, []map[string]any{{"msg": "hello"}}, jl.Buffer.Slice())
+	jl.Flush()
+	assert.Equal(t, []map[string]any{{"msg": "hello"}, {"msg": "tail"}}, jl.Buffer.Slice())
+}
+
+func Test_json_log_large_object(t *testing.T) {
+	// given a json log
+	jl := NewJSONLog(10)
+
+	// when a very large object is written in small chunks
+	fields := make(map[string]any)
+	for i := 0; i < 10000; i++ {
+		fields[fmt.Sprintf("field%d", i)] = strings.Repeat("v", 100)
+	}
+	line, _ := json.Marshal(fields)
+	data := append(line, '\n')
+	for len(data) > 0 {
+		n := 4096
+		if n > len(data) {
+			n = len(data)
+		}
+		jl.Write(data[:n])
+		data = data[n:]
+	}
+
+	// then it is decoded intact
+	assert.Equal(t, 1, jl.Buffer.Len())
+	assert.Zero(t, jl.pending.Len())
+	assert.Equal(t, fields, jl.Buffer.Slice()[0])
+}
+
+type testJSONEntry struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+func Test_json_log_custom_decoder(t *testing.T) {
+	// given a json log decoding into a struct
+	jl := NewJSONLogFunc(10,
+		func(line []byte) (testJSONEntry, error) {
+			var e testJSONEntry
+			err := json.Unmarshal(line, &e)
+			return e, err
+		},
+		func(line string) testJSONEntry {
+			return testJSONEntry{Level: "unknown", Msg: line}
+		})
+
+	// when valid and invalid lines are written
+	jl.Write([]byte(`{"level":"warn","msg":"slow"}` + "\nbroken\n"))
+
+	// then both are stored
+	assert.Equal(t, []testJSONEntry{
+		{Level: "warn", Msg: "slow"},
+		{Level: "unknown", Msg: "broken"},
+	}, jl.Buffer.Slice())
+}
+
+func Test_json_log_nil_fallback_drops_invalid_lines(t *testing.T) {
+	jl := NewJSONLogFunc(10,
+		func(line []byte) (int, error) {
+			var v int
+			err := json.Unmarshal(line, &v)
+			return v, err
+		}, nil)
+
+	jl.Write([]byte("1\nnot a number\n3\n"))
+
+	assert.Equal(t, []int{1, 3}, jl.Buffer.Slice())
+}
@@ -0,0 +1,126 @@
+package memlog
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// ParseLevel returns the level with the given name.  The
+// name is not case sensitive and "WARNING" is accepted as
+// an alias for LevelWarn.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARN", "WARNING":
+		return LevelWarn, nil
+	case "ERROR":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("memlog: unknown level %q", name)
+	}
+}
+
+// LevelEntry is a log entry with an associated severity.
+type LevelEntry[T any] struct {
+	Level Level
+	Value T
+}
+
+// LevelLog is a MemLog of entries with a severity level.
+// Entries below the minimum level are discarded when
+// they are appended.
+//
+// LevelLog is thread-safe
+type LevelLog[T any] struct {
+	Buffer   *MemLog[LevelEntry[T]]
+	minLevel atomic.Int32
+}
+
+// NewLevelLog returns a new LevelLog that will not grow
+// beyond size entries.  All levels are stored until
+// SetMinLevel is called.
+func NewLevelLog[T any](size int) *LevelLog[T] {
+	return &LevelLog[T]{
+		Buffer: NewMemLog[LevelEntry[T]](size),
+	}
+}
+
+// SetMinLevel causes entries below level to be
+// discarded when they are appended.
+func (l *LevelLog[T]) SetMinLevel(level Level) {
+	l.minLevel.Store(int32(level))
+}
+
+// Append adds v to the log at the specified level
+// unless level is below the minimum level.
+func (l *LevelLog[T]) Append(level Level, v T) {
+	if level < Level(l.minLevel.Load()) {
+		return
+	}
+	l.Buffer.Append(LevelEntry[T]{Level: level, Value: v})
+}
+
+// Debug adds v to the log at LevelDebug.
+func (l *LevelLog[T]) Debug(v T) {
+	l.Append(LevelDebug, v)
+}
+
+// Info adds v to the log at LevelInfo.
+func (l *LevelLog[T]) Info(v T) {
+	l.Append(LevelInfo, v)
+}
+
+// Warn adds v to the log at LevelWarn.
+func (l *LevelLog[T]) Warn(v T) {
+	l.Append(LevelWarn, v)
+}
+
+// Error adds v to the log at LevelError.
+func (l *LevelLog[T]) Error(v T) {
+	l.Append(LevelError, v)
+}
+
+// SliceLevel returns the values of entries at or above
+// minLevel.  The slice is ordered from oldest item to
+// the newest.
+func (l *LevelLog[T]) SliceLevel(minLevel Level) []T {
+	var slice []T
+
+	for _, entry := range l.Buffer.Slice() {
+		if entry.Level >= minLevel {
+			slice = append(slice, entry.Value)
+		}
+	}
+
+	return slice
+}
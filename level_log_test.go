@@ -0,0 +1,102 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_level_log_slice_level(t *testing.T) {
+	// given a level log with one entry at each level
+	log := NewLevelLog[string](10)
+	log.Debug("debug")
+	log.Info("info")
+	log.Warn("warn")
+	log.Error("error")
+
+	tests := []struct {
+		minLevel Level
+		want     []string
+	}{
+		{LevelDebug, []string{"debug", "info", "warn", "error"}},
+		{LevelInfo, []string{"info", "warn", "error"}},
+		{LevelWarn, []string{"warn", "error"}},
+		{LevelError, []string{"error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minLevel.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, log.SliceLevel(tt.minLevel))
+		})
+	}
+}
+
+func Test_level_log_set_min_level(t *testing.T) {
+	tests := []struct {
+		minLevel Level
+		want     []string
+	}{
+		{LevelDebug, []string{"debug", "info", "warn", "error"}},
+		{LevelInfo, []string{"info", "warn", "error"}},
+		{LevelWarn, []string{"warn", "error"}},
+		{LevelError, []string{"error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minLevel.String(), func(t *testing.T) {
+			// given a level log with a minimum level
+			log := NewLevelLog[string](10)
+			log.SetMinLevel(tt.minLevel)
+
+			// when entries are added at each level
+			log.Debug("debug")
+			log.Info("info")
+			log.Warn("warn")
+			log.Error("error")
+
+			// then entries below the minimum are not stored
+			assert.Equal(t, len(tt.want), log.Buffer.Len())
+			assert.Equal(t, tt.want, log.SliceLevel(LevelDebug))
+		})
+	}
+}
+
+func Test_level_string(t *testing.T) {
+	assert.Equal(t, "DEBUG", LevelDebug.String())
+	assert.Equal(t, "ERROR", LevelError.String())
+	assert.Equal(t, "LEVEL(9)", Level(9).String())
+}
+
+func Test_parse_level(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{"DEBUG", LevelDebug},
+		{"info", LevelInfo},
+		{"Warn", LevelWarn},
+		{"WARNING", LevelWarn},
+		{"error", LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseLevel(tt.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
+
+func Test_parse_level_round_trips(t *testing.T) {
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		parsed, err := ParseLevel(level.String())
+		assert.NoError(t, err)
+		assert.Equal(t, level, parsed)
+	}
+}
+
+func Test_parse_level_unknown(t *testing.T) {
+	_, err := ParseLevel("verbose")
+	assert.EqualError(t, err, `memlog: unknown level "verbose"`)
+}
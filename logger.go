@@ -0,0 +1,136 @@
+package memlog
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Entry is a formatted message stored by a Logger.  Caller
+// is only set when caller capture is enabled with
+// CaptureCallers.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Caller  *Caller `json:",omitempty"`
+}
+
+// String formats the entry as a single line, for
+// instance for use with Dump.
+func (e Entry) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Time.Format(time.RFC3339))
+	sb.WriteByte(' ')
+	sb.WriteString(e.Level.String())
+	sb.WriteByte(' ')
+	sb.WriteString(e.Message)
+	if e.Caller != nil {
+		sb.WriteString(" (")
+		sb.WriteString(e.Caller.String())
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// Logger is a severity-aware log of formatted messages
+// built on MemLog.  Every entry is stored regardless of
+// its level; filtering by level is done when reading.
+//
+// Logger is thread-safe
+type Logger struct {
+	Buffer        *MemLog[Entry]
+	captureCaller atomic.Bool
+	callerSkip    atomic.Int32
+}
+
+// NewLogger returns a new Logger that will not grow
+// beyond size entries.
+func NewLogger(size int, opts ...Option[Entry]) *Logger {
+	return &Logger{
+		Buffer: NewMemLog(size, opts...),
+	}
+}
+
+// CaptureCallers causes the file, line and function that
+// logged each subsequent entry to be recorded in its Caller
+// field.  skip is the number of additional stack frames to
+// skip, which allows helper functions that wrap the Logger
+// to report their own caller; 0 records the caller of Logf
+// or Debugf etc.  Capturing callers has a cost so it is
+// disabled by default.
+func (l *Logger) CaptureCallers(skip int) {
+	l.callerSkip.Store(int32(skip))
+	l.captureCaller.Store(true)
+}
+
+// Logf formats a message according to format and
+// adds it to the log at the specified level.
+func (l *Logger) Logf(level Level, format string, args ...any) {
+	l.log(level, format, args)
+}
+
+// log adds an entry to the log.  It must be called
+// directly by each exported logging method so that the
+// depth of the caller is the same for all of them.
+func (l *Logger) log(level Level, format string, args []any) {
+	// format and capture the caller outside of the buffer's lock
+	entry := Entry{
+		Time:    l.Buffer.now(),
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if l.captureCaller.Load() {
+		// skip log and the exported method that called it
+		entry.Caller = callerFrame(2 + int(l.callerSkip.Load()))
+	}
+
+	l.Buffer.Append(entry)
+}
+
+// Debugf adds a formatted message to the log at LevelDebug.
+func (l *Logger) Debugf(format string, args ...any) {
+	l.log(LevelDebug, format, args)
+}
+
+// Infof adds a formatted message to the log at LevelInfo.
+func (l *Logger) Infof(format string, args ...any) {
+	l.log(LevelInfo, format, args)
+}
+
+// Warnf adds a formatted message to the log at LevelWarn.
+func (l *Logger) Warnf(format string, args ...any) {
+	l.log(LevelWarn, format, args)
+}
+
+// Errorf adds a formatted message to the log at LevelError.
+func (l *Logger) Errorf(format string, args ...any) {
+	l.log(LevelError, format, args)
+}
+
+// Slice returns the entries at or above minLevel.
+// The slice is ordered from oldest item to the newest.
+func (l *Logger) Slice(minLevel Level) []Entry {
+	var slice []Entry
+
+	for _, entry := range l.Buffer.Slice() {
+		if entry.Level >= minLevel {
+			slice = append(slice, entry)
+		}
+	}
+
+	return slice
+}
+
+// Errors returns the last n entries at LevelError or
+// above, or all of them if n is negative.  The slice is
+// ordered from oldest item to the newest.
+func (l *Logger) Errors(n int) []Entry {
+	errors := l.Slice(LevelError)
+	if n > allElements && len(errors) > n {
+		errors = errors[len(errors)-n:]
+	}
+	return errors
+}
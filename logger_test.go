@@ -0,0 +1,171 @@
+package memlog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_logger_stores_formatted_entries(t *testing.T) {
+	// given a logger with a fixed clock
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	log := NewLogger(10, WithClock[Entry](NewFakeClock(start)))
+
+	// when messages are logged
+	log.Infof("started %d workers", 4)
+	log.Errorf("worker %s failed: %v", "w1", "timeout")
+
+	// then each entry holds the time, level and formatted message
+	assert.Equal(t, []Entry{
+		{Time: start, Level: LevelInfo, Message: "started 4 workers"},
+		{Time: start, Level: LevelError, Message: "worker w1 failed: timeout"},
+	}, log.Buffer.Slice())
+}
+
+func Test_logger_slice_min_level(t *testing.T) {
+	// given entries at mixed levels
+	log := NewLogger(10)
+	log.Debugf("d1")
+	log.Errorf("e1")
+	log.Infof("i1")
+	log.Warnf("w1")
+	log.Debugf("d2")
+	log.Errorf("e2")
+
+	tests := []struct {
+		minLevel Level
+		want     []string
+	}{
+		{LevelDebug, []string{"d1", "e1", "i1", "w1", "d2", "e2"}},
+		{LevelInfo, []string{"e1", "i1", "w1", "e2"}},
+		{LevelWarn, []string{"e1", "w1", "e2"}},
+		{LevelError, []string{"e1", "e2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minLevel.String(), func(t *testing.T) {
+			// then filtering preserves the original order
+			var got []string
+			for _, entry := range log.Slice(tt.minLevel) {
+				got = append(got, entry.Message)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_logger_stores_all_levels(t *testing.T) {
+	// given entries that are filtered out when reading
+	log := NewLogger(10)
+	log.Debugf("value %d", 1)
+	log.Errorf("value %d", 2)
+
+	// then they are still stored and formatted
+	assert.Len(t, log.Slice(LevelError), 1)
+	assert.Equal(t, 2, log.Buffer.Len())
+	assert.Equal(t, "value 1", log.Buffer.Slice()[0].Message)
+}
+
+func Test_logger_errors(t *testing.T) {
+	log := NewLogger(10)
+	log.Errorf("e1")
+	log.Infof("i1")
+	log.Errorf("e2")
+	log.Errorf("e3")
+
+	var got []string
+	for _, entry := range log.Errors(2) {
+		got = append(got, entry.Message)
+	}
+
+	assert.Equal(t, []string{"e2", "e3"}, got)
+	assert.Len(t, log.Errors(10), 3)
+	assert.Empty(t, log.Errors(0))
+}
+
+func Test_logger_errors_all(t *testing.T) {
+	// given several errors
+	log := NewLogger(10)
+	log.Errorf("e1")
+	log.Infof("i1")
+	log.Errorf("e2")
+
+	// when a negative count is requested
+	errors := log.Errors(allElements)
+
+	// then every error is returned
+	assert.Len(t, errors, 2)
+	assert.Len(t, log.Errors(-5), 2)
+}
+
+func Test_logger_callers_disabled_by_default(t *testing.T) {
+	log := NewLogger(10)
+	log.Infof("no caller")
+
+	assert.Nil(t, log.Buffer.Slice()[0].Caller)
+}
+
+func Test_logger_captures_caller(t *testing.T) {
+	// given a logger capturing callers
+	log := NewLogger(10)
+	log.CaptureCallers(0)
+
+	// when entries are logged with different methods
+	_, _, line, _ := runtime.Caller(0)
+	log.Infof("info")
+	log.Logf(LevelWarn, "warn")
+
+	// then each caller is this test
+	entries := log.Buffer.Slice()
+	for i, entry := range entries {
+		assert.Equal(t, "logger_test.go", filepath.Base(entry.Caller.File))
+		assert.Equal(t, line+1+i, entry.Caller.Line)
+		assert.Equal(t, "github.com/yabosh/memlog.Test_logger_captures_caller", entry.Caller.Function)
+	}
+}
+
+// logHelper wraps a Logger as application code might.
+func logHelper(log *Logger, msg string) {
+	log.Errorf("helper: %s", msg)
+}
+
+func Test_logger_caller_skip(t *testing.T) {
+	// given a logger that skips one frame for a helper
+	log := NewLogger(10)
+	log.CaptureCallers(1)
+
+	// when an entry is logged through the helper
+	_, _, line, _ := runtime.Caller(0)
+	logHelper(log, "failed")
+
+	// then the caller is the code calling the helper
+	caller := log.Buffer.Slice()[0].Caller
+	assert.Equal(t, line+1, caller.Line)
+	assert.Equal(t, "github.com/yabosh/memlog.Test_logger_caller_skip", caller.Function)
+}
+
+func Test_logger_caller_output(t *testing.T) {
+	// given an entry with a captured caller
+	log := NewLogger(10, WithClock[Entry](NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))))
+	log.CaptureCallers(0)
+	log.Warnf("disk low")
+	caller := log.Buffer.Slice()[0].Caller
+
+	// when the log is dumped and served as JSON
+	var dump strings.Builder
+	assert.NoError(t, log.Buffer.Dump(&dump, nil))
+
+	rec := httptest.NewRecorder()
+	NewHTTPHandler(log.Buffer).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// then the caller is included
+	assert.Equal(t, "2024-01-02T03:04:05Z WARN disk low ("+caller.String()+")\n", dump.String())
+	assert.Contains(t, rec.Body.String(), `"Caller":{"File":"`+caller.File+`","Line":`+strconv.Itoa(caller.Line))
+}
@@ -0,0 +1,116 @@
+package memlog
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRULog is a log of distinct values in which appending a
+// value that is already present moves it to the newest
+// position rather than adding a duplicate.  When the log is
+// full the least recently appended value is removed.
+//
+// LRULog is thread-safe
+type LRULog[T comparable] struct {
+	size     int
+	entries  *list.List
+	elements map[T]*list.Element
+	stats    Stats
+	locker   sync.Mutex
+}
+
+// NewLRULog returns a new LRULog that will not grow
+// beyond size entries.
+func NewLRULog[T comparable](size int) *LRULog[T] {
+	return &LRULog[T]{
+		size:     size,
+		entries:  list.New(),
+		elements: make(map[T]*list.Element),
+	}
+}
+
+// Append adds v as the newest entry.  If v is already in
+// the log it is moved to the newest position; otherwise, if
+// the log is full, the least recently used entry is removed
+// to make room.
+func (l *LRULog[T]) Append(v T) {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.stats.TotalAppends++
+	if e, ok := l.elements[v]; ok {
+		l.entries.MoveToBack(e)
+		return
+	}
+
+	if l.size <= 0 {
+		return
+	}
+
+	if l.entries.Len() >= l.size {
+		oldest := l.entries.Front()
+		l.entries.Remove(oldest)
+		delete(l.elements, oldest.Value.(T))
+		l.stats.TotalEvictions++
+	}
+
+	l.elements[v] = l.entries.PushBack(v)
+}
+
+// Len returns the number of entries in the log.
+func (l *LRULog[T]) Len() int {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	return l.entries.Len()
+}
+
+// Cap returns the maximum number of
+// entries the log will hold.
+func (l *LRULog[T]) Cap() int {
+	return l.size
+}
+
+// Stats returns a snapshot of the log's counters.  Only
+// TotalAppends and TotalEvictions are maintained; appending
+// a value already in the log counts as an append.
+func (l *LRULog[T]) Stats() Stats {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	return l.stats
+}
+
+// Slice returns the entries in the log ordered from the
+// least to the most recently used.
+func (l *LRULog[T]) Slice() []T {
+	return l.SliceN(allElements)
+}
+
+// SliceN returns the 'N' most recently used entries, or
+// all of them if n is negative.  The slice is ordered from
+// the least to the most recently used.
+func (l *LRULog[T]) SliceN(n int) []T {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	if n <= allElements || n > l.entries.Len() {
+		n = l.entries.Len()
+	}
+
+	slice := make([]T, n)
+	e := l.entries.Back()
+	for i := n - 1; i >= 0; i-- {
+		slice[i] = e.Value.(T)
+		e = e.Prev()
+	}
+	return slice
+}
+
+// Clear removes every entry from the log.  The
+// counters reported by Stats are kept.
+func (l *LRULog[T]) Clear() {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+
+	l.entries.Init()
+	clear(l.elements)
+}
@@ -0,0 +1,98 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lru_log_new_insert(t *testing.T) {
+	// given an empty LRU log
+	log := NewLRULog[string](3)
+
+	// when distinct values are appended
+	log.Append("a")
+	log.Append("b")
+
+	// then they are stored in order
+	assert.Equal(t, 2, log.Len())
+	assert.Equal(t, []string{"a", "b"}, log.Slice())
+}
+
+func Test_lru_log_reinsert_promotes(t *testing.T) {
+	// given an LRU log with several values
+	log := NewLRULog[string](3)
+	log.Append("a")
+	log.Append("b")
+	log.Append("c")
+
+	// when an existing value is appended again
+	log.Append("a")
+
+	// then it is moved to the newest position without a duplicate
+	assert.Equal(t, 3, log.Len())
+	assert.Equal(t, []string{"b", "c", "a"}, log.Slice())
+}
+
+func Test_lru_log_evicts_least_recently_used(t *testing.T) {
+	// given a full LRU log where the oldest value was promoted
+	log := NewLRULog[int](3)
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+	log.Append(1)
+
+	// when a new value is appended
+	log.Append(4)
+
+	// then the least recently used value is removed
+	assert.Equal(t, []int{3, 1, 4}, log.Slice())
+
+	// and an evicted value is inserted as new
+	log.Append(2)
+	assert.Equal(t, []int{1, 4, 2}, log.Slice())
+}
+
+func Test_lru_log_zero_size(t *testing.T) {
+	log := NewLRULog[int](0)
+	log.Append(1)
+	assert.Equal(t, 0, log.Len())
+}
+
+func Test_lru_log_slice_n(t *testing.T) {
+	// given an LRU log with a promoted value
+	log := NewLRULog[int](5)
+	for _, v := range []int{1, 2, 3, 1} {
+		log.Append(v)
+	}
+
+	// when the most recent entries are requested
+	// then they are ordered from least to most recently used
+	assert.Equal(t, []int{3, 1}, log.SliceN(2))
+	assert.Equal(t, []int{2, 3, 1}, log.SliceN(allElements))
+	assert.Equal(t, []int{2, 3, 1}, log.SliceN(10))
+	assert.Empty(t, log.SliceN(0))
+}
+
+func Test_lru_log_stats_and_clear(t *testing.T) {
+	// given a full LRU log that has evicted a value
+	log := NewLRULog[int](2)
+	for _, v := range []int{1, 2, 1, 3} {
+		log.Append(v)
+	}
+
+	// when it is cleared
+	log.Clear()
+
+	// then the entries are removed but the counters are kept
+	assert.Equal(t, 0, log.Len())
+	assert.Empty(t, log.Slice())
+	assert.Equal(t, 2, log.Cap())
+	stats := log.Stats()
+	assert.Equal(t, uint64(4), stats.TotalAppends)
+	assert.Equal(t, uint64(1), stats.TotalEvictions)
+
+	// and values can be appended again
+	log.Append(2)
+	assert.Equal(t, []int{2}, log.Slice())
+}
@@ -1,15 +1,39 @@
 package memlog
 
 import (
-	"container/list"
+	"context"
+	"errors"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 const (
 	allElements = -1
 )
 
-// MemLog is a bounded linked list that is intended
+var (
+	// ErrFull is returned by AppendErr when the log was
+	// created with WithRejectWhenFull and is full.
+	ErrFull = errors.New("memlog: log is full")
+
+	// ErrRateLimited is returned by AppendErr when the log was
+	// created with WithRateLimit and the limit was exceeded.
+	ErrRateLimited = errors.New("memlog: append rate limit exceeded")
+
+	// ErrSampledOut is returned by AppendErr when the log was
+	// created with WithSampling and the entry was not sampled.
+	ErrSampledOut = errors.New("memlog: entry not sampled")
+
+	// ErrOverflow is returned by AppendErr when the log was
+	// created with WithOverflowError and entries were evicted
+	// to make room for the new entry.  The entry was added.
+	ErrOverflow = errors.New("memlog: entries evicted")
+)
+
+// MemLog is a bounded ring buffer that is intended
 // used as a mechanism for logging information
 // in memory.  The log has a fixed length and
 // supports automatically removing older entries
@@ -30,9 +54,203 @@ const (
 //
 // MemLog is thread-safe
 type MemLog[T any] struct {
-	lst    list.List
-	size   int
-	locker sync.Mutex
+	entries    ring[T]
+	size       int
+	maxBytes   int
+	oversized  bool
+	now        func() time.Time
+	lastAppend atomic.Int64
+	subs       map[chan T]struct{}
+	seq        uint64
+	merge      func(last, item T) (T, bool)
+	order      func(a, b T) bool
+	onFull     func()
+	onEvict    func(T)
+	onAppend   func(T)
+	evicted    []T
+	initial    []T
+	full       bool
+	reject     bool
+	overflow   bool
+	rw         bool
+	freed      chan struct{}
+	pool       *sync.Pool
+	sizer      func(T) int
+	intern     func(T) T
+	limiter    *tokenBucket
+	suppressed uint64
+	suppress   func(n uint64) T
+	sample     func() bool
+	timeOf     func(T) time.Time
+	lastTime   time.Time
+	outOfOrder bool
+	bytes      int
+	stats      Stats
+	cleared    Stats
+	locker     sync.RWMutex
+}
+
+// sequenced is an entry along with the order
+// in which it was appended.
+type sequenced[T any] struct {
+	seq   uint64
+	value T
+}
+
+// Stats holds counters describing the
+// activity of a MemLog over its lifetime.
+type Stats struct {
+	// TotalAppends is the number of entries appended.
+	TotalAppends uint64
+
+	// TotalEvictions is the number of entries removed
+	// to make room for newer entries.
+	TotalEvictions uint64
+
+	// TotalRejections is the number of entries that
+	// were not appended because the log was full.
+	TotalRejections uint64
+
+	// InternHits and InternMisses count the entries that
+	// were and were not found in the intern table of a log
+	// created with WithInterning.
+	InternHits   uint64
+	InternMisses uint64
+
+	// TotalRateLimited is the number of entries dropped
+	// because the log's rate limit was exceeded.
+	TotalRateLimited uint64
+
+	// TotalSampledOut is the number of entries dropped
+	// because they were not selected by sampling.
+	TotalSampledOut uint64
+
+	// OutOfOrder is set when an entry of a timestamped log
+	// was appended with an earlier timestamp than the entry
+	// before it, which causes time queries to scan the log.
+	OutOfOrder bool
+
+	// BinarySearches and LinearScans count the time queries
+	// that located entries using a binary search and those
+	// that scanned the log because OutOfOrder was set.
+	BinarySearches uint64
+	LinearScans    uint64
+
+	// Bytes is an estimate of the memory
+	// retained by the entries in the log.
+	Bytes int
+}
+
+// Option configures a MemLog.
+type Option[T any] func(*MemLog[T])
+
+// WithOnFull causes fn to be called once when the log
+// first reaches its maximum size, which is the point at
+// which older entries start being discarded.  The callback
+// is re-armed when the log is cleared.
+//
+// fn is called without the log's lock held so it may
+// safely append to the log.
+func WithOnFull[T any](fn func()) Option[T] {
+	return func(m *MemLog[T]) {
+		m.onFull = fn
+	}
+}
+
+// WithRejectWhenFull causes entries appended to a full log
+// to be rejected rather than evicting the oldest entry.
+// Append silently discards rejected entries; use AppendErr
+// or TryAppend to detect them.
+func WithRejectWhenFull[T any]() Option[T] {
+	return func(m *MemLog[T]) {
+		m.reject = true
+	}
+}
+
+// WithInitialEntries causes the log to be created holding
+// entries, ordered from oldest to newest.  If there are more
+// entries than the log holds only the newest are kept.
+func WithInitialEntries[T any](entries []T) Option[T] {
+	return func(m *MemLog[T]) {
+		m.initial = entries
+	}
+}
+
+// WithOnEvict sets a function to be called with each entry
+// evicted to make room for a newer entry.  It is called after
+// the log is unlocked so it may use the log.
+func WithOnEvict[T any](fn func(item T)) Option[T] {
+	return func(m *MemLog[T]) {
+		m.onEvict = fn
+	}
+}
+
+// WithOnAppend sets a function to be called with each entry
+// accepted by the log, including entries merged into the
+// newest entry by WithCoalesce.  It is called after the log
+// is unlocked so it may use the log.
+func WithOnAppend[T any](fn func(item T)) Option[T] {
+	return func(m *MemLog[T]) {
+		m.onAppend = fn
+	}
+}
+
+// WithOverflowError causes AppendErr to return ErrOverflow
+// when entries were evicted to make room for the new entry,
+// so that callers can detect lost entries.
+func WithOverflowError[T any]() Option[T] {
+	return func(m *MemLog[T]) {
+		m.overflow = true
+	}
+}
+
+// WithRWLock allows methods that only read the log, such as
+// Slice, Len and the aggregate functions, to run concurrently
+// with each other.  This benefits logs that are read far more
+// often than they are appended to.
+func WithRWLock[T any]() Option[T] {
+	return func(m *MemLog[T]) {
+		m.rw = true
+	}
+}
+
+// WithClock sets the clock used for the log's time-based
+// features, including timestamps, Age and rate limiting.
+func WithClock[T any](c Clock) Option[T] {
+	return func(m *MemLog[T]) {
+		m.now = c.Now
+	}
+}
+
+// WithSizer causes fn to be used to estimate the number of
+// bytes retained by each entry, as reported by Bytes.
+func WithSizer[T any](fn func(T) int) Option[T] {
+	return func(m *MemLog[T]) {
+		m.sizer = fn
+	}
+}
+
+// defaultSizer returns a function estimating the bytes retained
+// by an entry.  Strings and byte slices are measured by their
+// header plus their length; other types by their shallow size.
+func defaultSizer[T any]() func(T) int {
+	var zero T
+	header := int(unsafe.Sizeof(zero))
+
+	switch any(zero).(type) {
+	case string:
+		return func(item T) int {
+			return header + len(any(item).(string))
+		}
+	case []byte:
+		return func(item T) int {
+			return header + len(any(item).([]byte))
+		}
+	default:
+		return func(T) int {
+			return header
+		}
+	}
 }
 
 // NewMemLog returns a new, initialized instance of memlog
@@ -40,31 +258,498 @@ type MemLog[T any] struct {
 // entries.  Once the log reaches the maximum number of
 // entries, as new entries are added, the oldest entries
 // are removed.
-func NewMemLog[T any](size int) *MemLog[T] {
-	return &MemLog[T]{
+func NewMemLog[T any](size int, opts ...Option[T]) *MemLog[T] {
+	m := &MemLog[T]{
 		size: size,
+		now:  SystemClock.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
 	}
+
+	if m.sizer == nil {
+		m.sizer = defaultSizer[T]()
+	}
+
+	if m.initial != nil {
+		m.prefill()
+	}
+
+	return m
+}
+
+// prefill adds the entries provided by WithInitialEntries
+// without counting them in Stats or notifying callbacks.
+func (m *MemLog[T]) prefill() {
+	initial := m.initial
+	if len(initial) > m.size {
+		initial = initial[len(initial)-max(m.size, 0):]
+	}
+
+	for _, item := range initial {
+		m.push(item)
+	}
+
+	m.initial = nil
+	m.evicted = nil
+	m.stats = Stats{}
 }
 
 // Len returns the number of elements in
 // the log
 func (m *MemLog[T]) Len() int {
-	m.locker.Lock()
-	defer m.locker.Unlock()
-	return m.lst.Len()
+	m.rlock()
+	defer m.runlock()
+	return m.entries.len()
+}
+
+// rlock locks the log for reading.  Readers share the
+// lock if the log was created with WithRWLock.
+func (m *MemLog[T]) rlock() {
+	if m.rw {
+		m.locker.RLock()
+	} else {
+		m.locker.Lock()
+	}
+}
+
+// runlock unlocks a lock taken by rlock.
+func (m *MemLog[T]) runlock() {
+	if m.rw {
+		m.locker.RUnlock()
+	} else {
+		m.locker.Unlock()
+	}
+}
+
+// Cap returns the maximum number of entries the log
+// will hold or, for a log created by NewCappedLog, the
+// maximum number of bytes.
+func (m *MemLog[T]) Cap() int {
+	if m.maxBytes > 0 {
+		return m.maxBytes
+	}
+	return m.size
+}
+
+// Stats returns a snapshot of the log's counters.
+func (m *MemLog[T]) Stats() Stats {
+	m.rlock()
+	defer m.runlock()
+
+	stats := m.stats
+	stats.Bytes = m.bytes
+	stats.OutOfOrder = m.outOfOrder
+	return stats
+}
+
+// Bytes returns an estimate of the memory retained by the
+// entries in the log.  The estimate is maintained as entries
+// are added and removed.  Use WithSizer to customize how
+// entries are measured.
+func (m *MemLog[T]) Bytes() int {
+	m.rlock()
+	defer m.runlock()
+	return m.bytes
+}
+
+// removeFront removes and returns the oldest entry.
+// The caller must hold the lock.
+func (m *MemLog[T]) removeFront() T {
+	item := m.entries.popFront().value
+	m.forget(item)
+	return item
+}
+
+// evict removes the oldest entry to make room for a
+// newer entry.  The caller must hold the lock.
+func (m *MemLog[T]) evict() {
+	m.discard(m.removeFront())
+}
+
+// discard records that item was evicted, either from the
+// log or because it could not be stored.  The caller must
+// hold the lock.
+func (m *MemLog[T]) discard(item T) {
+	m.stats.TotalEvictions++
+	if m.onEvict != nil {
+		m.evicted = append(m.evicted, item)
+	}
+}
+
+// takeEvicted returns the entries evicted since it was
+// last called.  The caller must hold the lock.
+func (m *MemLog[T]) takeEvicted() []T {
+	evicted := m.evicted
+	m.evicted = nil
+	return evicted
+}
+
+// notify calls the callbacks for an append of item that
+// returned err.  It must be called after the lock is
+// released.
+func (m *MemLog[T]) notify(item T, err error, evicted []T, fireOnFull bool) {
+	for _, e := range evicted {
+		m.onEvict(e)
+	}
+
+	if m.onAppend != nil && (err == nil || err == ErrOverflow) {
+		m.onAppend(item)
+	}
+
+	if fireOnFull {
+		m.onFull()
+	}
+}
+
+// set replaces the value of entry with item.
+// The caller must hold the lock.
+func (m *MemLog[T]) set(entry *sequenced[T], item T) {
+	m.forget(entry.value)
+	m.remember(item)
+	entry.value = item
+}
+
+// remember accounts for item being stored in the log.
+// The caller must hold the lock.
+func (m *MemLog[T]) remember(item T) {
+	m.bytes += m.sizer(item)
+}
+
+// forget accounts for item being removed from the log.
+// The caller must hold the lock.
+func (m *MemLog[T]) forget(item T) {
+	m.bytes -= m.sizer(item)
+}
+
+// clearEntries removes every entry from the log.
+// The caller must hold the lock.
+func (m *MemLog[T]) clearEntries() {
+	m.entries.reset()
+	m.bytes = 0
 }
 
 // Append will add item to the log.  If the
 // log has reached its maximum size the the oldest
 // entry will be removed to make room for the new entry.
 func (m *MemLog[T]) Append(item T) {
+	m.append(item, m.reject, true)
+}
+
+// AppendLog appends the entries of other to the log, ordered
+// from oldest to newest, evicting entries as the log's size
+// requires.  other is copied before the log is locked, so a
+// log may be appended to itself, and other is not modified.
+func (m *MemLog[T]) AppendLog(other *MemLog[T]) {
+	items := other.Slice()
+
+	m.locker.Lock()
+	errs := make([]error, len(items))
+	fireOnFull := false
+	for i, item := range items {
+		var fire bool
+		errs[i], fire = m.appendLocked(item, m.reject, true)
+		fireOnFull = fireOnFull || fire
+	}
+	evicted := m.takeEvicted()
+	m.locker.Unlock()
+
+	for _, e := range evicted {
+		m.onEvict(e)
+	}
+
+	if m.onAppend != nil {
+		for i, item := range items {
+			if errs[i] == nil || errs[i] == ErrOverflow {
+				m.onAppend(item)
+			}
+		}
+	}
+
+	if fireOnFull {
+		m.onFull()
+	}
+}
+
+// AppendAlways is like Append but bypasses sampling, so that
+// entries that must be kept, such as errors, are always added
+// to a log created with WithSampling.
+func (m *MemLog[T]) AppendAlways(item T) {
+	m.append(item, m.reject, false)
+}
+
+// AppendErr is like Append but returns an error if item
+// was not added: ErrFull if the log was created with
+// WithRejectWhenFull and is full, ErrRateLimited if the
+// log was created with WithRateLimit and the limit was
+// exceeded, or ErrSampledOut if the log was created with
+// WithSampling and item was not sampled.  If the log was
+// created with WithOverflowError it also returns ErrOverflow
+// when item was added but entries were evicted.
+func (m *MemLog[T]) AppendErr(item T) error {
+	return m.append(item, m.reject, true)
+}
+
+// TryAppend adds item to the log only if the log is not
+// full and reports whether it was added.  The log is not
+// modified when it is full.
+func (m *MemLog[T]) TryAppend(item T) bool {
+	return m.append(item, true, true) == nil
+}
+
+// AppendWait adds item to the log, blocking while the log is
+// full until space is made available by Pop, Drain or Clear,
+// or until ctx is done.  This allows a MemLog to be used as a
+// bounded hand-off buffer between a producer and a consumer.
+//
+// AppendWait may be used alongside Append on the same log, but
+// Append continues to evict the oldest entry when the log is
+// full, so entries may be lost if both are used.
+func (m *MemLog[T]) AppendWait(ctx context.Context, item T) error {
+	for {
+		m.locker.Lock()
+		if m.entries.len() < m.size {
+			err, fireOnFull := m.appendLocked(item, false, true)
+			evicted := m.takeEvicted()
+			m.locker.Unlock()
+
+			m.notify(item, err, evicted, fireOnFull)
+			return err
+		}
+
+		if m.freed == nil {
+			m.freed = make(chan struct{})
+		}
+		freed := m.freed
+		m.locker.Unlock()
+
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// append adds item to the log, evicting the oldest entry
+// or, if reject is set, rejecting item when the log is
+// full.  If sample is set item may be dropped by sampling.
+// It returns an error if item was not added.
+func (m *MemLog[T]) append(item T, reject, sample bool) error {
+	m.locker.Lock()
+	err, fireOnFull := m.appendLocked(item, reject, sample)
+	evicted := m.takeEvicted()
+	m.locker.Unlock()
+
+	m.notify(item, err, evicted, fireOnFull)
+	return err
+}
+
+// appendBuilt adds the item returned by build.  build is
+// called while holding the lock so that values taken from the
+// clock are in the same order as the entries holding them.
+func (m *MemLog[T]) appendBuilt(build func() T) error {
+	m.locker.Lock()
+	item := build()
+	err, fireOnFull := m.appendLocked(item, m.reject, true)
+	evicted := m.takeEvicted()
+	m.locker.Unlock()
+
+	m.notify(item, err, evicted, fireOnFull)
+	return err
+}
+
+// appendLocked adds item to the log and returns an error if
+// it was not added, or ErrOverflow, along with whether the
+// OnFull callback should be called.  The caller must hold the
+// lock and must call notify after releasing it.
+func (m *MemLog[T]) appendLocked(item T, reject, sample bool) (err error, fireOnFull bool) {
+	if sample && m.sample != nil && !m.sample() {
+		m.stats.TotalSampledOut++
+		return ErrSampledOut, false
+	}
+
+	if reject && m.entries.len() >= m.size {
+		m.stats.TotalRejections++
+		return ErrFull, false
+	}
+
+	if m.limiter != nil {
+		if !m.limiter.allow(m.now()) {
+			m.stats.TotalRateLimited++
+			m.suppressed++
+			return ErrRateLimited, false
+		}
+
+		if m.suppressed > 0 && m.suppress != nil {
+			m.push(m.suppress(m.suppressed))
+		}
+		m.suppressed = 0
+	}
+
+	evictions := m.stats.TotalEvictions
+	m.push(item)
+	if m.overflow && m.stats.TotalEvictions > evictions {
+		err = ErrOverflow
+	}
+
+	fireOnFull = m.onFull != nil && !m.full && m.entries.len() >= m.size
+	if fireOnFull {
+		m.full = true
+	}
+
+	return err, fireOnFull
+}
+
+// push adds item to the end of the log, evicting the oldest
+// entry if the log is full.  The caller must hold the lock.
+func (m *MemLog[T]) push(item T) {
+	m.lastAppend.Store(m.now().UnixNano())
+	if m.intern != nil {
+		item = m.intern(item)
+	}
+
+	if m.merge != nil && m.entries.len() > 0 {
+		back := m.entries.back()
+		if merged, ok := m.merge(back.value, item); ok {
+			m.set(back, merged)
+			return
+		}
+	}
+
+	m.publish(item)
+	m.seq++
+	m.stats.TotalAppends++
+	if m.size <= 0 {
+		m.discard(item)
+		return
+	}
+
+	if m.order != nil {
+		m.insertSorted(item)
+		return
+	}
+
+	cost := m.sizer(item)
+	if m.maxBytes > 0 {
+		if cost > m.maxBytes && !m.oversized {
+			m.discard(item)
+			return
+		}
+		for m.entries.len() > 0 && m.bytes+cost > m.maxBytes {
+			m.evict()
+		}
+	}
+
+	if m.entries.len() >= m.size {
+		m.evict()
+	}
+	m.entries.pushBack(sequenced[T]{seq: m.seq - 1, value: item}, m.size)
+	m.remember(item)
+
+	if m.timeOf != nil {
+		t := m.timeOf(item)
+		if t.Before(m.lastTime) {
+			m.outOfOrder = true
+		}
+		m.lastTime = t
+	}
+}
+
+// resetOrder records that the log is empty and so its
+// entries are in time order.  The caller must hold the lock.
+func (m *MemLog[T]) resetOrder() {
+	m.lastTime = time.Time{}
+	m.outOfOrder = false
+}
+
+// signalFreed wakes callers of AppendWait after entries
+// have been removed.  The caller must hold the lock.
+func (m *MemLog[T]) signalFreed() {
+	if m.freed != nil {
+		close(m.freed)
+		m.freed = nil
+	}
+}
+
+// Subscribe returns a channel that receives each entry
+// appended to the log after Subscribe returns, along with a
+// function that ends the subscription and closes the channel.
+//
+// The channel has capacity buffer.  Entries are delivered
+// without blocking Append, so when a subscriber falls behind
+// and its channel is full new entries are dropped for that
+// subscriber.
+func (m *MemLog[T]) Subscribe(buffer int) (<-chan T, func()) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	return m.subscribe(buffer)
+}
+
+// SubscribeWithBacklog atomically returns the current contents
+// of the log and subscribes to new entries, as Subscribe does,
+// so that no entry is missed or delivered twice.
+func (m *MemLog[T]) SubscribeWithBacklog(buffer int) ([]T, <-chan T, func()) {
 	m.locker.Lock()
 	defer m.locker.Unlock()
 
-	m.lst.PushBack(item)
-	if m.lst.Len() > m.size {
-		m.lst.Remove(m.lst.Front())
+	backlog := m.toSlice(m.entries.len())
+	ch, cancel := m.subscribe(buffer)
+	return backlog, ch, cancel
+}
+
+// Subscribers returns the number of subscriptions
+// that have not been cancelled.
+func (m *MemLog[T]) Subscribers() int {
+	m.rlock()
+	defer m.runlock()
+	return len(m.subs)
+}
+
+// subscribe registers a new subscriber.  The
+// caller must hold the lock.
+func (m *MemLog[T]) subscribe(buffer int) (<-chan T, func()) {
+	ch := make(chan T, buffer)
+	if m.subs == nil {
+		m.subs = make(map[chan T]struct{})
 	}
+	m.subs[ch] = struct{}{}
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			m.locker.Lock()
+			defer m.locker.Unlock()
+			delete(m.subs, ch)
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+// publish delivers item to each subscriber that has room
+// for it.  The caller must hold the lock.
+func (m *MemLog[T]) publish(item T) {
+	for ch := range m.subs {
+		select {
+		case ch <- item:
+		default:
+		}
+	}
+}
+
+// Age returns the time elapsed since the most recent
+// call to Append.  Age returns 0 when the log is empty.
+//
+// Age is useful for health checks that need to detect
+// when no new entries have been logged recently.
+func (m *MemLog[T]) Age() time.Duration {
+	if m.Len() == 0 {
+		return 0
+	}
+	return m.now().Sub(time.Unix(0, m.lastAppend.Load()))
 }
 
 // Slice returns the contents of the log as a slice.
@@ -73,21 +758,176 @@ func (m *MemLog[T]) Slice() (slice []T) {
 	return m.SliceN(allElements)
 }
 
-// Clear will clear the current contents of the memLog
+// Clear removes every entry from the log and resets the
+// counters reported by Stats, returning the log to the
+// state it was in when it was created.  Use Reset to keep
+// the counters.  The counters reported by LifetimeStats
+// are not reset.
 func (m *MemLog[T]) Clear() {
 	m.locker.Lock()
 	defer m.locker.Unlock()
-	m.lst.Init()
+	m.reset()
+	m.cleared.TotalAppends += m.stats.TotalAppends
+	m.cleared.TotalEvictions += m.stats.TotalEvictions
+	m.stats = Stats{}
+}
+
+// LifetimeStats returns the counters reported by Stats
+// including those accumulated before the log was last
+// cleared, so they never decrease.
+func (m *MemLog[T]) LifetimeStats() Stats {
+	m.rlock()
+	defer m.runlock()
+
+	stats := m.stats
+	stats.TotalAppends += m.cleared.TotalAppends
+	stats.TotalEvictions += m.cleared.TotalEvictions
+	return stats
+}
+
+// Reset removes every entry from the log but, unlike Clear,
+// keeps the counters reported by Stats, such as TotalAppends
+// and TotalEvictions.
+func (m *MemLog[T]) Reset() {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.reset()
+}
+
+// reset removes every entry from the log.
+// The caller must hold the lock.
+func (m *MemLog[T]) reset() {
+	m.clearEntries()
+	m.full = false
+	m.resetOrder()
+	m.signalFreed()
+}
+
+// Pop removes and returns the oldest entry in the
+// log, or false if the log is empty.
+func (m *MemLog[T]) Pop() (item T, ok bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	if m.entries.len() == 0 {
+		return item, false
+	}
+
+	m.signalFreed()
+	return m.removeFront(), true
+}
+
+// Drain removes and returns the contents of the log.
+// The slice is ordered from oldest item to the newest
+func (m *MemLog[T]) Drain() []T {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	slice := m.toSlice(m.entries.len())
+	m.clearEntries()
+	m.resetOrder()
+	m.signalFreed()
+	return slice
+}
+
+// RemoveIf removes each entry for which predicate returns
+// true and returns the number of entries removed.  This is
+// useful for expiring entries that have been processed.
+func (m *MemLog[T]) RemoveIf(predicate func(T) bool) int {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	return m.removeIf(predicate)
+}
+
+// removeIf removes every entry for which predicate returns
+// true.  The caller must hold the lock.
+func (m *MemLog[T]) removeIf(predicate func(T) bool) int {
+	// move the entries that are kept towards the front
+	kept := 0
+	for i := 0; i < m.entries.len(); i++ {
+		entry := m.entries.at(i)
+		if predicate(entry.value) {
+			m.forget(entry.value)
+			continue
+		}
+		*m.entries.at(kept) = *entry
+		kept++
+	}
+
+	removed := m.entries.len() - kept
+	m.entries.truncate(kept)
+
+	if removed > 0 {
+		m.signalFreed()
+	}
+
+	return removed
+}
+
+// removeFrontWhile removes entries from the front of the log
+// until predicate returns false for the oldest remaining entry.
+// The caller must hold the lock.
+func (m *MemLog[T]) removeFrontWhile(predicate func(T) bool) int {
+	removed := 0
+	for m.entries.len() > 0 && predicate(m.entries.at(0).value) {
+		m.removeFront()
+		removed++
+	}
+
+	if removed > 0 {
+		m.signalFreed()
+	}
+
+	return removed
+}
+
+// Replace sets the entry at position index, where 0 is the
+// oldest entry, to value.  It returns false if index is out
+// of range.  The position of the entry is not changed.
+func (m *MemLog[T]) Replace(index int, value T) bool {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	if index < 0 || index >= m.entries.len() {
+		return false
+	}
+
+	m.set(m.entries.at(index), value)
+	if m.timeOf != nil {
+		m.outOfOrder = true
+	}
+
+	return true
+}
+
+// Sort sorts the entries in the log in place using less.
+// The sort is stable, so equal entries keep their order.
+func (m *MemLog[T]) Sort(less func(a, b T) bool) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	slice := m.toSlice(m.entries.len())
+	sort.SliceStable(slice, func(i, j int) bool {
+		return less(slice[i], slice[j])
+	})
+
+	for i, item := range slice {
+		m.entries.at(i).value = item
+	}
+
+	if m.timeOf != nil {
+		m.outOfOrder = true
+	}
 }
 
 // SliceN returns the last 'N' items
 // from the log.
 // The slice is ordered from oldest item to the newest
 func (m *MemLog[T]) SliceN(n int) (slice []T) {
-	m.locker.Lock()
-	defer m.locker.Unlock()
+	m.rlock()
+	defer m.runlock()
 
-	len := m.lst.Len()
+	len := m.entries.len()
 
 	if n <= allElements || n > len {
 		n = len
@@ -96,39 +936,117 @@ func (m *MemLog[T]) SliceN(n int) (slice []T) {
 	return m.toSlice(n)
 }
 
+// Window returns a new MemLog with capacity n holding a copy
+// of the last n entries of this log.  Unlike SliceN the result
+// can be passed to functions expecting a MemLog.  The new log
+// is independent of this one.
+func (m *MemLog[T]) Window(n int) *MemLog[T] {
+	if n < 0 {
+		n = 0
+	}
+
+	window := NewMemLog[T](n)
+	window.now = m.now
+	for _, item := range m.SliceN(n) {
+		window.Append(item)
+	}
+
+	return window
+}
+
+// SortedSlice returns a copy of the contents of the log
+// sorted using less.  The sort is stable, so equal entries
+// are ordered from oldest to newest.  The log itself is not
+// reordered.
+func (m *MemLog[T]) SortedSlice(less func(a, b T) bool) []T {
+	return m.SortedSliceN(allElements, less)
+}
+
+// SortedSliceN is like SortedSlice but only
+// includes the last 'N' items from the log.
+func (m *MemLog[T]) SortedSliceN(n int, less func(a, b T) bool) []T {
+	slice := m.SliceN(n)
+	sort.SliceStable(slice, func(i, j int) bool {
+		return less(slice[i], slice[j])
+	})
+	return slice
+}
+
+// Chunk returns the contents of the log partitioned into
+// slices of at most size entries, ordered from oldest to
+// newest.  The last chunk may be smaller.  Chunk returns nil
+// if the log is empty or size is less than 1.
+func (m *MemLog[T]) Chunk(size int) [][]T {
+	if size <= 0 {
+		return nil
+	}
+
+	slice := m.Slice()
+	if len(slice) == 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(slice)+size-1)/size)
+	for size < len(slice) {
+		chunks = append(chunks, slice[:size:size])
+		slice = slice[size:]
+	}
+
+	return append(chunks, slice)
+}
+
+// Partition returns the entries for which predicate returns
+// true in matching and all other entries in rest.  Both slices
+// are ordered from oldest item to the newest.
+func (m *MemLog[T]) Partition(predicate func(T) bool) (matching, rest []T) {
+	m.rlock()
+	defer m.runlock()
+
+	m.forEachN(allElements, func(item T) {
+		if predicate(item) {
+			matching = append(matching, item)
+		} else {
+			rest = append(rest, item)
+		}
+	})
+
+	return matching, rest
+}
+
+// forEachN calls fn with each of the last n entries,
+// ordered from oldest to newest.  The caller must hold
+// the lock.
+func (m *MemLog[T]) forEachN(n int, fn func(item T)) {
+	if n <= allElements || n > m.entries.len() {
+		n = m.entries.len()
+	}
+
+	for i := m.entries.len() - n; i < m.entries.len(); i++ {
+		fn(m.entries.at(i).value)
+	}
+}
+
 // toSlice creates a slice of the last 'n' elements
 // of the log.
 func (m *MemLog[T]) toSlice(n int) (slice []T) {
 	slice = make([]T, n)
-	idx := n - 1
+	first := m.entries.len() - n
 
-	// Walk the list 'backward', filling in the slice
-	// from the last element to the zero element.  This
-	// is more efficient than searching 'forward' when n < m.lst.Len()
-	for e := m.lst.Back(); e != nil && idx >= 0; e = e.Prev() {
-		slice[idx] = e.Value.(T)
-		idx--
+	for i := range slice {
+		slice[i] = m.entries.at(first + i).value
 	}
 
 	return slice
 }
 
-// toSlice will copy a range of elements in the linked
-// list to a slice
+// toSlice will copy a range of elements in the
+// log to a slice
 func (m *MemLog[T]) toSlicex(n int, len int) (slice []T) {
 	first := len - n
 	slice = make([]T, n)
-	ptr := 0
-	item := m.lst.Front()
 
-	for i := 0; i < len; i++ {
-		if i < first {
-			item = item.Next()
-			continue
-		}
-		slice[ptr] = item.Value.(T)
-		item = item.Next()
-		ptr++
+	for i := range slice {
+		slice[i] = m.entries.at(first + i).value
 	}
 
 	return slice
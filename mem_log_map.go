@@ -0,0 +1,113 @@
+package memlog
+
+import (
+	"container/list"
+	"sync"
+)
+
+// keyedLog is the log of a single key in a MemLogMap.
+type keyedLog[K comparable, V any] struct {
+	key K
+	log *MemLog[V]
+}
+
+// MemLogMap holds a bounded MemLog for each key, for instance
+// to keep the last events for every customer.  The number of
+// keys may also be bounded, in which case the least recently
+// used key is removed, along with its log, when a new key is
+// added.
+//
+// MemLogMap is thread-safe.  Appends to different keys only
+// contend while the key's log is looked up.
+type MemLogMap[K comparable, V any] struct {
+	size    int
+	maxKeys int
+	keys    map[K]*list.Element
+	lru     *list.List
+	locker  sync.Mutex
+}
+
+// NewMemLogMap returns a MemLogMap that keeps at most size
+// entries for each key and at most maxKeys keys.  If maxKeys
+// is less than 1 the number of keys is not bounded.
+func NewMemLogMap[K comparable, V any](size, maxKeys int) *MemLogMap[K, V] {
+	return &MemLogMap[K, V]{
+		size:    size,
+		maxKeys: maxKeys,
+		keys:    make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Append adds v to the log of key, creating the log if
+// this is the first entry for key.
+func (m *MemLogMap[K, V]) Append(key K, v V) {
+	m.locker.Lock()
+	log := m.get(key, true)
+	m.locker.Unlock()
+
+	log.Append(v)
+}
+
+// Slice returns the entries of key ordered from oldest
+// to newest, or nil if key has no log.
+func (m *MemLogMap[K, V]) Slice(key K) []V {
+	m.locker.Lock()
+	log := m.get(key, false)
+	m.locker.Unlock()
+
+	if log == nil {
+		return nil
+	}
+	return log.Slice()
+}
+
+// Keys returns the keys that have a log, ordered from
+// least to most recently used.
+func (m *MemLogMap[K, V]) Keys() []K {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	keys := make([]K, 0, m.lru.Len())
+	for e := m.lru.Back(); e != nil; e = e.Prev() {
+		keys = append(keys, e.Value.(*keyedLog[K, V]).key)
+	}
+	return keys
+}
+
+// DeleteKey removes key and its log.
+func (m *MemLogMap[K, V]) DeleteKey(key K) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	if e, ok := m.keys[key]; ok {
+		m.lru.Remove(e)
+		delete(m.keys, key)
+	}
+}
+
+// get returns the log of key and marks key as the most
+// recently used.  If key has no log and create is set a
+// log is added, removing the least recently used key if
+// there are too many keys; otherwise get returns nil.
+// The caller must hold the lock.
+func (m *MemLogMap[K, V]) get(key K, create bool) *MemLog[V] {
+	if e, ok := m.keys[key]; ok {
+		m.lru.MoveToFront(e)
+		return e.Value.(*keyedLog[K, V]).log
+	}
+
+	if !create {
+		return nil
+	}
+
+	if m.maxKeys > 0 && m.lru.Len() >= m.maxKeys {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.keys, oldest.Value.(*keyedLog[K, V]).key)
+	}
+
+	kl := &keyedLog[K, V]{key: key, log: NewMemLog[V](m.size)}
+	m.keys[key] = m.lru.PushFront(kl)
+	return kl.log
+}
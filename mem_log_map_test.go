@@ -0,0 +1,83 @@
+package memlog
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mem_log_map_per_key_wrapping(t *testing.T) {
+	// given a map keeping two entries per key
+	m := NewMemLogMap[string, int](2, 0)
+
+	// when more entries are added to one key than it holds
+	m.Append("a", 1)
+	m.Append("a", 2)
+	m.Append("a", 3)
+	m.Append("b", 10)
+
+	// then only that key's oldest entry is evicted
+	assert.Equal(t, []int{2, 3}, m.Slice("a"))
+	assert.Equal(t, []int{10}, m.Slice("b"))
+	assert.Nil(t, m.Slice("c"))
+}
+
+func Test_mem_log_map_key_eviction_order(t *testing.T) {
+	// given a map holding at most three keys
+	m := NewMemLogMap[string, int](5, 3)
+	m.Append("a", 1)
+	m.Append("b", 1)
+	m.Append("c", 1)
+
+	// when "a" is used and a new key is added
+	m.Append("a", 2)
+	m.Append("d", 1)
+
+	// then the least recently used key is removed
+	assert.Equal(t, []string{"c", "a", "d"}, m.Keys())
+	assert.Nil(t, m.Slice("b"))
+
+	// and reading a key marks it as used
+	m.Slice("c")
+	m.Append("e", 1)
+	assert.Equal(t, []string{"d", "c", "e"}, m.Keys())
+}
+
+func Test_mem_log_map_delete_key(t *testing.T) {
+	m := NewMemLogMap[string, int](5, 2)
+	m.Append("a", 1)
+	m.Append("b", 1)
+
+	m.DeleteKey("a")
+	m.DeleteKey("missing")
+	m.Append("c", 1)
+
+	assert.Equal(t, []string{"b", "c"}, m.Keys())
+	assert.Nil(t, m.Slice("a"))
+}
+
+func Test_mem_log_map_concurrent_appends(t *testing.T) {
+	// given a map with room for every key
+	m := NewMemLogMap[string, int](100, 0)
+
+	// when many goroutines append to many keys
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Append(fmt.Sprintf("key-%d", i%20), i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then every key holds every entry appended to it
+	assert.Len(t, m.Keys(), 20)
+	for i := 0; i < 20; i++ {
+		assert.Len(t, m.Slice(fmt.Sprintf("key-%d", i)), 50)
+	}
+}
@@ -1,9 +1,14 @@
 package memlog
 
 import (
+	"context"
 	"fmt"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -180,3 +185,829 @@ func Benchmark_memlog_list_get_slice_pointers(b *testing.B) {
 		_ = slice
 	}
 }
+
+func Test_memlog_age(t *testing.T) {
+	// given a memlog with a fake clock
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	log := NewMemLog(10, WithClock[string](clock))
+
+	// then an empty log has no age
+	assert.Zero(t, log.Age())
+
+	// when an entry is appended and time passes
+	log.Append("item #1")
+	clock.Advance(5 * time.Second)
+
+	// then the age is the time since the append
+	assert.Equal(t, 5*time.Second, log.Age())
+
+	// when another entry is appended
+	log.Append("item #2")
+	clock.Advance(time.Second)
+
+	// then the age is measured from the newest entry
+	assert.Equal(t, time.Second, log.Age())
+
+	// and a cleared log has no age
+	log.Clear()
+	assert.Zero(t, log.Age())
+}
+
+func Test_memlog_subscribe(t *testing.T) {
+	// given a log with an existing entry and a subscriber
+	log := NewMemLog[string](10)
+	log.Append("before")
+	entries, cancel := log.Subscribe(10)
+
+	// when entries are appended
+	log.Append("item #1")
+	log.Append("item #2")
+
+	// then only new entries are delivered
+	assert.Equal(t, "item #1", <-entries)
+	assert.Equal(t, "item #2", <-entries)
+
+	// and cancelling closes the channel
+	cancel()
+	cancel()
+	_, ok := <-entries
+	assert.False(t, ok)
+	log.Append("after")
+}
+
+func Test_memlog_subscribe_drops_when_full(t *testing.T) {
+	// given a subscriber that is not reading
+	log := NewMemLog[int](10)
+	entries, cancel := log.Subscribe(2)
+	defer cancel()
+
+	// when more entries are appended than the channel holds
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+	}
+
+	// then appends are not blocked and extra entries are dropped
+	assert.Equal(t, 0, <-entries)
+	assert.Equal(t, 1, <-entries)
+	assert.Len(t, entries, 0)
+	assert.Equal(t, 5, log.Len())
+}
+
+func Test_memlog_stats(t *testing.T) {
+	// given a memlog
+	log := NewMemLog[int](2)
+	assert.Equal(t, 2, log.Cap())
+	assert.Equal(t, Stats{}, log.Stats())
+
+	// when more entries are appended than it holds
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+
+	// then the counters reflect the appends and evictions
+	assert.Equal(t, Stats{TotalAppends: 3, TotalEvictions: 1, Bytes: 16}, log.Stats())
+}
+
+func Test_memlog_on_full_fires_once(t *testing.T) {
+	// given a log with an OnFull callback
+	var calls atomic.Int32
+	log := NewMemLog[int](10, WithOnFull[int](func() { calls.Add(1) }))
+
+	// when many entries are appended concurrently
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				log.Append(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then the callback is invoked exactly once
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_memlog_on_full_rearmed_by_clear(t *testing.T) {
+	// given a log that has filled up
+	calls := 0
+	log := NewMemLog[int](2, WithOnFull[int](func() { calls++ }))
+	log.Append(1)
+	assert.Equal(t, 0, calls)
+	log.Append(2)
+	log.Append(3)
+	assert.Equal(t, 1, calls)
+
+	// when it is cleared and fills up again
+	log.Clear()
+	log.Append(4)
+	log.Append(5)
+
+	// then the callback is invoked again
+	assert.Equal(t, 2, calls)
+}
+
+func Test_memlog_on_full_may_append(t *testing.T) {
+	// given a callback that appends to the same log
+	var log *MemLog[string]
+	log = NewMemLog[string](2, WithOnFull[string](func() {
+		log.Append("full")
+	}))
+
+	// when the log fills up
+	log.Append("a")
+	log.Append("b")
+
+	// then it does not deadlock
+	assert.Equal(t, []string{"b", "full"}, log.Slice())
+}
+
+func Test_memlog_try_append(t *testing.T) {
+	// given a full log
+	log := NewMemLog[int](2)
+	assert.True(t, log.TryAppend(1))
+	assert.True(t, log.TryAppend(2))
+
+	// when another entry is offered
+	ok := log.TryAppend(3)
+
+	// then it is rejected and the log is unchanged
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 2}, log.Slice())
+	assert.Equal(t, Stats{TotalAppends: 2, TotalRejections: 1, Bytes: 16}, log.Stats())
+
+	// and accepted again once the log is cleared
+	log.Clear()
+	assert.True(t, log.TryAppend(3))
+	assert.Equal(t, []int{3}, log.Slice())
+}
+
+func Test_memlog_reject_when_full(t *testing.T) {
+	// given a log that rejects entries when full
+	log := NewMemLog[string](2, WithRejectWhenFull[string]())
+	assert.NoError(t, log.AppendErr("a"))
+	log.Append("b")
+
+	// when more entries are appended
+	err := log.AppendErr("c")
+	log.Append("d")
+
+	// then they are rejected and the oldest entries are kept
+	assert.ErrorIs(t, err, ErrFull)
+	assert.Equal(t, []string{"a", "b"}, log.Slice())
+	assert.Equal(t, uint64(2), log.Stats().TotalRejections)
+
+	log.Clear()
+	assert.NoError(t, log.AppendErr("e"))
+	assert.Equal(t, []string{"e"}, log.Slice())
+}
+
+func Test_memlog_append_err_evicting(t *testing.T) {
+	log := NewMemLog[int](1)
+	assert.NoError(t, log.AppendErr(1))
+	assert.NoError(t, log.AppendErr(2))
+	assert.Equal(t, []int{2}, log.Slice())
+}
+
+func Test_memlog_pop_and_drain(t *testing.T) {
+	// given a log with entries
+	log := NewMemLog[int](5)
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+
+	// when the oldest entry is popped and the rest drained
+	item, ok := log.Pop()
+	drained := log.Drain()
+
+	// then entries are removed oldest first
+	assert.True(t, ok)
+	assert.Equal(t, 1, item)
+	assert.Equal(t, []int{2, 3}, drained)
+	assert.Zero(t, log.Len())
+
+	_, ok = log.Pop()
+	assert.False(t, ok)
+	assert.Empty(t, log.Drain())
+}
+
+func Test_memlog_append_wait_unblocked_by_consumer(t *testing.T) {
+	// given a full log
+	log := NewMemLog[int](2)
+	ctx := context.Background()
+	assert.NoError(t, log.AppendWait(ctx, 1))
+	assert.NoError(t, log.AppendWait(ctx, 2))
+
+	// when a producer appends more entries than fit
+	done := make(chan error)
+	go func() {
+		for i := 3; i <= 6; i++ {
+			if err := log.AppendWait(ctx, i); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	// then it blocks until a consumer makes room
+	var received []int
+	for len(received) < 6 {
+		if item, ok := log.Pop(); ok {
+			received = append(received, item)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, received)
+	assert.Zero(t, log.Stats().TotalEvictions)
+}
+
+func Test_memlog_append_wait_unblocked_by_drain(t *testing.T) {
+	log := NewMemLog[int](1)
+	log.Append(1)
+
+	done := make(chan error)
+	go func() { done <- log.AppendWait(context.Background(), 2) }()
+
+	select {
+	case <-done:
+		t.Fatal("AppendWait returned while log was full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.Equal(t, []int{1}, log.Drain())
+	assert.NoError(t, <-done)
+	assert.Equal(t, []int{2}, log.Slice())
+}
+
+func Test_memlog_append_wait_cancelled(t *testing.T) {
+	// given a full log
+	log := NewMemLog[int](1)
+	log.Append(1)
+
+	// when the context is cancelled while blocked
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- log.AppendWait(ctx, 2) }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// then the context error is returned and the log is unchanged
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, []int{1}, log.Slice())
+}
+
+func Test_memlog_remove_if(t *testing.T) {
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	tests := []struct {
+		name      string
+		items     []int
+		predicate func(int) bool
+		removed   int
+		want      []int
+	}{
+		{"none", []int{1, 3, 5}, isEven, 0, []int{1, 3, 5}},
+		{"all", []int{2, 4, 6}, isEven, 3, []int{}},
+		{"middle", []int{1, 2, 3, 4, 5}, isEven, 2, []int{1, 3, 5}},
+		{"empty", nil, isEven, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given a log with entries
+			log := NewMemLog[int](10)
+			for _, item := range tt.items {
+				log.Append(item)
+			}
+
+			// when matching entries are removed
+			removed := log.RemoveIf(tt.predicate)
+
+			// then only the remaining entries are kept
+			assert.Equal(t, tt.removed, removed)
+			assert.Equal(t, tt.want, log.Slice())
+			assert.Equal(t, len(tt.want), log.Len())
+		})
+	}
+}
+
+func Test_memlog_replace(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		ok    bool
+		want  []string
+	}{
+		{"first", 0, true, []string{"x", "b", "c"}},
+		{"middle", 1, true, []string{"a", "x", "c"}},
+		{"last", 2, true, []string{"a", "b", "x"}},
+		{"negative", -1, false, []string{"a", "b", "c"}},
+		{"past end", 3, false, []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given a log with entries
+			log := NewMemLog[string](5)
+			log.Append("a")
+			log.Append("b")
+			log.Append("c")
+
+			// when an entry is replaced
+			ok := log.Replace(tt.index, "x")
+
+			// then only that entry is changed
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, log.Slice())
+			assert.Equal(t, 3, log.Len())
+		})
+	}
+}
+
+func Test_memlog_bytes_strings(t *testing.T) {
+	// given a log of strings
+	log := NewMemLog[string](2)
+	header := int(unsafe.Sizeof(""))
+	assert.Zero(t, log.Bytes())
+
+	// when entries are appended and evicted
+	log.Append("abc")
+	assert.Equal(t, header+3, log.Bytes())
+	log.Append("defgh")
+	log.Append("ij")
+
+	// then the estimate tracks the retained entries
+	assert.Equal(t, 2*header+7, log.Bytes())
+	assert.Equal(t, log.Bytes(), log.Stats().Bytes)
+
+	log.Replace(0, "")
+	assert.Equal(t, 2*header+2, log.Bytes())
+	log.Pop()
+	assert.Equal(t, header+2, log.Bytes())
+	log.Clear()
+	assert.Zero(t, log.Bytes())
+}
+
+func Test_memlog_bytes_struct(t *testing.T) {
+	type point struct{ X, Y int64 }
+	log := NewMemLog[point](3)
+
+	for i := 0; i < 5; i++ {
+		log.Append(point{})
+	}
+	assert.Equal(t, 3*16, log.Bytes())
+
+	log.RemoveIf(func(point) bool { return true })
+	assert.Zero(t, log.Bytes())
+}
+
+func Test_memlog_bytes_with_sizer(t *testing.T) {
+	log := NewMemLog[[]int](10, WithSizer(func(v []int) int { return 8 * len(v) }))
+	log.Append([]int{1, 2, 3})
+	log.Append([]int{4})
+
+	assert.Equal(t, 32, log.Bytes())
+	assert.Equal(t, [][]int{{1, 2, 3}, {4}}, log.Drain())
+	assert.Zero(t, log.Bytes())
+}
+
+func Test_memlog_sort(t *testing.T) {
+	// given a log with unordered entries
+	log := NewMemLog[int](10)
+	for _, v := range []int{3, 1, 4, 1, 5, 9, 2} {
+		log.Append(v)
+	}
+
+	// when sorted ascending then descending
+	log.Sort(func(a, b int) bool { return a < b })
+	ascending := log.Slice()
+	log.Sort(func(a, b int) bool { return a > b })
+
+	// then subsequent slices reflect the order
+	assert.Equal(t, []int{1, 1, 2, 3, 4, 5, 9}, ascending)
+	assert.Equal(t, []int{9, 5, 4, 3, 2, 1, 1}, log.Slice())
+}
+
+func Test_memlog_sort_stable(t *testing.T) {
+	type entry struct {
+		key   int
+		order string
+	}
+
+	log := NewMemLog[entry](10)
+	log.Append(entry{2, "a"})
+	log.Append(entry{1, "b"})
+	log.Append(entry{2, "c"})
+	log.Append(entry{1, "d"})
+
+	log.Sort(func(a, b entry) bool { return a.key < b.key })
+
+	assert.Equal(t, []entry{{1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}}, log.Slice())
+}
+
+func Test_memlog_chunk(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+		size  int
+		want  [][]int
+	}{
+		{"even", 6, 2, [][]int{{0, 1}, {2, 3}, {4, 5}}},
+		{"uneven", 5, 2, [][]int{{0, 1}, {2, 3}, {4}}},
+		{"larger than log", 3, 10, [][]int{{0, 1, 2}}},
+		{"size of one", 3, 1, [][]int{{0}, {1}, {2}}},
+		{"empty log", 0, 2, nil},
+		{"zero size", 3, 0, nil},
+		{"negative size", 3, -1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given a log with entries
+			log := NewMemLog[int](10)
+			for i := 0; i < tt.items; i++ {
+				log.Append(i)
+			}
+
+			// then it is partitioned into chunks
+			assert.Equal(t, tt.want, log.Chunk(tt.size))
+		})
+	}
+}
+
+func Test_memlog_partition(t *testing.T) {
+	// given a log with entries
+	log := NewMemLog[int](10)
+	for _, v := range []int{5, 2, 8, 1, 4, 7} {
+		log.Append(v)
+	}
+
+	// when partitioned by a predicate
+	even, odd := log.Partition(func(i int) bool { return i%2 == 0 })
+
+	// then each group keeps its original order
+	assert.Equal(t, []int{2, 8, 4}, even)
+	assert.Equal(t, []int{5, 1, 7}, odd)
+	assert.ElementsMatch(t, log.Slice(), append(even, odd...))
+}
+
+func Test_memlog_partition_empty(t *testing.T) {
+	matching, rest := NewMemLog[int](10).Partition(func(int) bool { return true })
+
+	assert.Empty(t, matching)
+	assert.Empty(t, rest)
+}
+
+func Test_memlog_sorted_slice(t *testing.T) {
+	type event struct {
+		name     string
+		duration int
+	}
+
+	// given a log of unordered events
+	log := NewMemLog[event](10)
+	log.Append(event{"a", 30})
+	log.Append(event{"b", 10})
+	log.Append(event{"c", 20})
+	log.Append(event{"d", 10})
+	byDuration := func(a, b event) bool { return a.duration < b.duration }
+
+	// when sorted views are requested
+	sorted := log.SortedSlice(byDuration)
+	lastTwo := log.SortedSliceN(2, byDuration)
+
+	// then the views are sorted stably and the log is unchanged
+	assert.Equal(t, []event{{"b", 10}, {"d", 10}, {"c", 20}, {"a", 30}}, sorted)
+	assert.Equal(t, []event{{"d", 10}, {"c", 20}}, lastTwo)
+	assert.Equal(t, []event{{"a", 30}, {"b", 10}, {"c", 20}, {"d", 10}}, log.Slice())
+}
+
+func Test_memlog_window(t *testing.T) {
+	// given a log with entries
+	log := NewMemLog[int](10)
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	// when a window of the last entries is taken
+	window := log.Window(3)
+
+	// then it holds those entries with capacity n
+	assert.Equal(t, []int{3, 4, 5}, window.Slice())
+	assert.Equal(t, 3, window.Cap())
+
+	// and is independent of the original
+	log.Append(6)
+	window.Append(7)
+	assert.Equal(t, []int{4, 5, 7}, window.Slice())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, log.Slice())
+}
+
+func Test_memlog_window_larger_than_log(t *testing.T) {
+	log := NewMemLog[int](10)
+	log.Append(1)
+
+	window := log.Window(5)
+
+	assert.Equal(t, []int{1}, window.Slice())
+	assert.Equal(t, 5, window.Cap())
+	assert.Zero(t, log.Window(0).Len())
+}
+
+func Test_memlog_append_log_to_empty(t *testing.T) {
+	// given an empty log and a log with entries
+	log := NewMemLog[int](5)
+	other := NewMemLog[int](5)
+	other.Append(1)
+	other.Append(2)
+
+	// when the entries are appended
+	log.AppendLog(other)
+
+	// then the log holds them in order and other is unchanged
+	assert.Equal(t, []int{1, 2}, log.Slice())
+	assert.Equal(t, []int{1, 2}, other.Slice())
+}
+
+func Test_memlog_append_log_to_full(t *testing.T) {
+	// given a full log
+	log := NewMemLog[int](3)
+	for i := 1; i <= 3; i++ {
+		log.Append(i)
+	}
+	other := NewMemLog[int](5)
+	other.Append(10)
+	other.Append(20)
+
+	// when another log's entries are appended
+	log.AppendLog(other)
+
+	// then the oldest entries are evicted
+	assert.Equal(t, []int{3, 10, 20}, log.Slice())
+	assert.Equal(t, uint64(2), log.Stats().TotalEvictions)
+	assert.Equal(t, []int{10, 20}, other.Slice())
+}
+
+func Test_memlog_append_log_to_itself(t *testing.T) {
+	log := NewMemLog[int](5)
+	log.Append(1)
+	log.Append(2)
+
+	log.AppendLog(log)
+
+	assert.Equal(t, []int{1, 2, 1, 2}, log.Slice())
+}
+
+func Test_memlog_without_options(t *testing.T) {
+	// given a log created without options
+	log := NewMemLog[int](2)
+
+	// when it overflows
+	assert.NoError(t, log.AppendErr(1))
+	assert.NoError(t, log.AppendErr(2))
+	assert.NoError(t, log.AppendErr(3))
+
+	// then the oldest entry is evicted without an error
+	assert.Equal(t, []int{2, 3}, log.Slice())
+}
+
+func Test_memlog_with_on_evict(t *testing.T) {
+	// given a log reporting evictions
+	var evicted []int
+	var log *MemLog[int]
+	log = NewMemLog(2, WithOnEvict(func(item int) {
+		// the log is unlocked when the callback is called
+		evicted = append(evicted, item*10+log.Len())
+	}))
+
+	// when it overflows
+	for i := 1; i <= 4; i++ {
+		log.Append(i)
+	}
+
+	// then each evicted entry is reported once, in order
+	assert.Equal(t, []int{12, 22}, evicted)
+}
+
+func Test_memlog_with_on_append(t *testing.T) {
+	var appended []string
+	log := NewMemLog(2, WithOnAppend(func(item string) {
+		appended = append(appended, item)
+	}), WithRejectWhenFull[string]())
+
+	log.Append("a")
+	log.Append("b")
+	log.Append("c")
+
+	assert.Equal(t, []string{"a", "b"}, appended)
+}
+
+func Test_memlog_with_coalesce(t *testing.T) {
+	// given a log coalescing entries with the same key
+	type event struct {
+		key string
+		n   int
+	}
+	log := NewMemLog(10, WithCoalesce(func(a, b event) bool {
+		return a.key == b.key
+	}))
+
+	// when consecutive entries have the same key
+	log.Append(event{"a", 1})
+	log.Append(event{"a", 2})
+	log.Append(event{"b", 3})
+	log.Append(event{"a", 4})
+
+	// then only the first of each run is stored
+	assert.Equal(t, []event{{"a", 1}, {"b", 3}, {"a", 4}}, log.Slice())
+}
+
+func Test_memlog_with_overflow_error(t *testing.T) {
+	// given a log reporting overflow
+	log := NewMemLog(2, WithOverflowError[int]())
+
+	// when entries are appended beyond its size
+	assert.NoError(t, log.AppendErr(1))
+	assert.NoError(t, log.AppendErr(2))
+	err := log.AppendErr(3)
+
+	// then the entry is added and the overflow is reported
+	assert.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, []int{2, 3}, log.Slice())
+}
+
+func Test_memlog_with_rw_lock(t *testing.T) {
+	// given a log whose readers share the lock
+	log := NewMemLog(100, WithRWLock[int]())
+
+	// when it is read and written concurrently
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				log.Append(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				log.Slice()
+				log.Len()
+				Sum(log)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then every append is recorded
+	assert.Equal(t, uint64(400), log.Stats().TotalAppends)
+	assert.Equal(t, 100, log.Len())
+}
+
+func Test_memlog_option_combinations(t *testing.T) {
+	type options struct {
+		evict, append, coalesce, rwLock, overflow bool
+	}
+
+	var combos []options
+	for bits := 0; bits < 32; bits++ {
+		combos = append(combos, options{
+			evict:    bits&1 != 0,
+			append:   bits&2 != 0,
+			coalesce: bits&4 != 0,
+			rwLock:   bits&8 != 0,
+			overflow: bits&16 != 0,
+		})
+	}
+
+	for _, o := range combos {
+		t.Run(fmt.Sprintf("%+v", o), func(t *testing.T) {
+			// given a log with a combination of options
+			var evicted, appended []int
+			var opts []Option[int]
+			if o.evict {
+				opts = append(opts, WithOnEvict(func(item int) { evicted = append(evicted, item) }))
+			}
+			if o.append {
+				opts = append(opts, WithOnAppend(func(item int) { appended = append(appended, item) }))
+			}
+			if o.coalesce {
+				opts = append(opts, WithCoalesce(func(a, b int) bool { return a == b }))
+			}
+			if o.rwLock {
+				opts = append(opts, WithRWLock[int]())
+			}
+			if o.overflow {
+				opts = append(opts, WithOverflowError[int]())
+			}
+			log := NewMemLog(2, opts...)
+
+			// when entries, including a repeat, overflow the log
+			var errs []error
+			for _, v := range []int{1, 2, 2, 3} {
+				errs = append(errs, log.AppendErr(v))
+			}
+
+			// then each option behaves independently of the others
+			want := []int{2, 3}
+			wantEvicted := []int{1}
+			wantErrs := []error{nil, nil, nil, nil}
+			if !o.coalesce {
+				wantEvicted = []int{1, 2}
+				if o.overflow {
+					wantErrs = []error{nil, nil, ErrOverflow, ErrOverflow}
+				}
+			} else if o.overflow {
+				wantErrs = []error{nil, nil, nil, ErrOverflow}
+			}
+
+			assert.Equal(t, want, log.Slice())
+			assert.Equal(t, wantErrs, errs)
+			if o.evict {
+				assert.Equal(t, wantEvicted, evicted)
+			}
+			if o.append {
+				assert.Equal(t, []int{1, 2, 2, 3}, appended)
+			}
+		})
+	}
+}
+
+func Test_memlog_with_initial_entries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []int
+		want    []int
+	}{
+		{"fewer than capacity", []int{1, 2}, []int{1, 2}},
+		{"equal to capacity", []int{1, 2, 3}, []int{1, 2, 3}},
+		{"more than capacity", []int{1, 2, 3, 4, 5}, []int{3, 4, 5}},
+		{"empty", []int{}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given a log created with initial entries
+			log := NewMemLog(3, WithInitialEntries(tt.entries))
+
+			// then it holds the newest entries without counting them
+			assert.Equal(t, tt.want, log.Slice())
+			assert.Equal(t, Stats{Bytes: 8 * len(tt.want)}, log.Stats())
+
+			// and new entries are appended after them
+			log.Append(9)
+			assert.Equal(t, 9, log.SliceN(1)[0])
+		})
+	}
+}
+
+func Test_memlog_with_initial_entries_zero_size(t *testing.T) {
+	log := NewMemLog(0, WithInitialEntries([]int{1, 2}))
+	assert.Equal(t, 0, log.Len())
+}
+
+func Test_memlog_reset_keeps_stats(t *testing.T) {
+	// given a log that has evicted entries
+	log := NewMemLog[int](2)
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+	}
+
+	// when it is reset
+	log.Reset()
+
+	// then the entries are removed but the counters are kept
+	assert.Equal(t, 0, log.Len())
+	assert.Empty(t, log.Slice())
+	stats := log.Stats()
+	assert.Equal(t, uint64(5), stats.TotalAppends)
+	assert.Equal(t, uint64(3), stats.TotalEvictions)
+	assert.Zero(t, stats.Bytes)
+
+	// and counting continues from them
+	log.Append(5)
+	assert.Equal(t, uint64(6), log.Stats().TotalAppends)
+}
+
+func Test_memlog_clear_resets_stats(t *testing.T) {
+	// given a log that has evicted entries
+	log := NewMemLog[int](2)
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+	}
+
+	// when it is cleared
+	log.Clear()
+
+	// then the entries and the counters are removed
+	assert.Equal(t, 0, log.Len())
+	assert.Equal(t, Stats{}, log.Stats())
+}
@@ -0,0 +1,140 @@
+// Package memloggrpc provides gRPC server interceptors that
+// record a summary of each RPC in a memlog.  It is separate
+// from package memlog so that only programs using it depend
+// on gRPC.
+package memloggrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yabosh/memlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RPCRecord summarizes an RPC handled by a server using
+// the interceptors.  Request is only set for unary RPCs
+// when WithRequests is used.
+type RPCRecord struct {
+	Time     time.Time
+	Method   string
+	Code     codes.Code
+	Duration time.Duration
+	PeerAddr string
+	Request  string
+}
+
+// Option configures the interceptors.
+type Option func(*options)
+
+type options struct {
+	clock      memlog.Clock
+	requestLen int
+}
+
+// WithRequests causes the request message of each unary RPC
+// to be recorded, truncated to at most maxLen bytes.
+func WithRequests(maxLen int) Option {
+	return func(o *options) {
+		o.requestLen = maxLen
+	}
+}
+
+// WithClock sets the clock used to timestamp
+// and time RPCs.
+func WithClock(c memlog.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{clock: memlog.SystemClock}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// UnaryServerInterceptor returns an interceptor that appends
+// a record of each unary RPC to m once it has been handled.
+func UnaryServerInterceptor(m *memlog.MemLog[RPCRecord], opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := o.clock.Now()
+		resp, err := handler(ctx, req)
+
+		record := o.record(ctx, info.FullMethod, start, err)
+		if o.requestLen > 0 {
+			record.Request = truncate(fmt.Sprint(req), o.requestLen)
+		}
+		m.Append(record)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that appends
+// a record of each streaming RPC to m once it has ended.
+func StreamServerInterceptor(m *memlog.MemLog[RPCRecord], opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := o.clock.Now()
+		err := handler(srv, ss)
+
+		m.Append(o.record(ss.Context(), info.FullMethod, start, err))
+		return err
+	}
+}
+
+// record returns a record of an RPC that started at start
+// and returned err.
+func (o *options) record(ctx context.Context, method string, start time.Time, err error) RPCRecord {
+	record := RPCRecord{
+		Time:     start,
+		Method:   method,
+		Code:     Code(err),
+		Duration: o.clock.Now().Sub(start),
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		record.PeerAddr = p.Addr.String()
+	}
+
+	return record
+}
+
+// Code returns the status code the server sends for err.
+// Context errors are converted to codes.Canceled and
+// codes.DeadlineExceeded and other errors without a status
+// to codes.Unknown.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+
+	return status.FromContextError(err).Code()
+}
+
+// truncate returns s shortened to at most max bytes
+// without splitting a UTF-8 sequence.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	for max > 0 && s[max]&0xC0 == 0x80 {
+		max--
+	}
+	return s[:max]
+}
@@ -0,0 +1,151 @@
+package memloggrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yabosh/memlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stepClock returns a clock that advances by step
+// each time it is read.
+func stepClock(step time.Duration) memlog.Clock {
+	var locker sync.Mutex
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return memlog.ClockFunc(func() time.Time {
+		locker.Lock()
+		defer locker.Unlock()
+		now = now.Add(step)
+		return now
+	})
+}
+
+// newHealthClient starts an in-process health server using
+// the interceptors and returns a client connected to it.
+func newHealthClient(t *testing.T, rpcs *memlog.MemLog[RPCRecord], opts ...Option) (healthpb.HealthClient, *health.Server) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(rpcs, opts...)),
+		grpc.StreamInterceptor(StreamServerInterceptor(rpcs, opts...)),
+	)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn), healthServer
+}
+
+func Test_unary_success(t *testing.T) {
+	// given a server recording RPCs with a stepping clock
+	rpcs := memlog.NewMemLog[RPCRecord](10)
+	client, _ := newHealthClient(t, rpcs, WithClock(stepClock(10*time.Millisecond)))
+
+	// when a unary RPC succeeds
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	// then it is recorded
+	records := rpcs.Slice()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "/grpc.health.v1.Health/Check", records[0].Method)
+	assert.Equal(t, codes.OK, records[0].Code)
+	assert.Equal(t, 10*time.Millisecond, records[0].Duration)
+	assert.Equal(t, "bufconn", records[0].PeerAddr)
+	assert.Empty(t, records[0].Request)
+}
+
+func Test_unary_error_with_request(t *testing.T) {
+	// given a server recording requests
+	rpcs := memlog.NewMemLog[RPCRecord](10)
+	client, _ := newHealthClient(t, rpcs, WithRequests(12))
+
+	// when a unary RPC fails
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "payments"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// then its code and truncated request are recorded
+	records := rpcs.Slice()
+	assert.Len(t, records, 1)
+	assert.Equal(t, codes.NotFound, records[0].Code)
+	assert.Len(t, records[0].Request, 12)
+	assert.Contains(t, records[0].Request, "service")
+}
+
+func Test_stream_canceled(t *testing.T) {
+	// given a server recording RPCs
+	rpcs := memlog.NewMemLog[RPCRecord](10)
+	client, _ := newHealthClient(t, rpcs)
+
+	// when a streaming RPC is canceled by the client
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	cancel()
+
+	// then it is recorded as canceled once the stream ends
+	assert.Eventually(t, func() bool { return rpcs.Len() == 1 }, time.Second, 10*time.Millisecond)
+	record := rpcs.Slice()[0]
+	assert.Equal(t, "/grpc.health.v1.Health/Watch", record.Method)
+	assert.Equal(t, codes.Canceled, record.Code)
+	assert.Empty(t, record.Request)
+}
+
+func Test_unary_context_errors(t *testing.T) {
+	// given an interceptor and handlers returning context errors
+	rpcs := memlog.NewMemLog[RPCRecord](10)
+	interceptor := UnaryServerInterceptor(rpcs)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+
+	for _, err := range []error{context.Canceled, context.DeadlineExceeded} {
+		// when the handler fails
+		_, got := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+		assert.Equal(t, err, got)
+	}
+
+	// then the errors are converted to their status codes
+	records := rpcs.Slice()
+	assert.Equal(t, codes.Canceled, records[0].Code)
+	assert.Equal(t, codes.DeadlineExceeded, records[1].Code)
+	assert.Empty(t, records[0].PeerAddr)
+}
+
+func Test_code(t *testing.T) {
+	assert.Equal(t, codes.OK, Code(nil))
+	assert.Equal(t, codes.PermissionDenied, Code(status.Error(codes.PermissionDenied, "no")))
+	assert.Equal(t, codes.Canceled, Code(context.Canceled))
+	assert.Equal(t, codes.Unknown, Code(errors.New("failed")))
+}
+
+func Test_truncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abc", truncate("abcdef", 3))
+	assert.Equal(t, "a", truncate("aé", 2))
+}
@@ -0,0 +1,48 @@
+// Package memloglogrus provides a logrus hook that stores
+// formatted log entries in a memlog.  It is separate from
+// package memlog so that only programs using it depend on
+// logrus.
+package memloglogrus
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yabosh/memlog"
+)
+
+// Hook is a logrus.Hook that stores each formatted
+// log entry in a MemLog.
+type Hook struct {
+	buffer *memlog.MemLog[string]
+}
+
+// NewHook returns a Hook that will keep
+// at most size entries.
+func NewHook(size int) *Hook {
+	return &Hook{
+		buffer: memlog.NewMemLog[string](size),
+	}
+}
+
+// Buffer returns the MemLog holding the formatted entries.
+func (h *Hook) Buffer() *memlog.MemLog[string] {
+	return h.buffer
+}
+
+// Levels returns all logrus levels so every entry is captured.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire formats entry using the logger's formatter and
+// appends the result to the buffer.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+
+	h.buffer.Append(strings.TrimRight(line, "\r\n"))
+	return nil
+}
@@ -0,0 +1,53 @@
+package memloglogrus
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(hook *Hook) *logrus.Logger {
+	logger := logrus.New()
+	logger.Out = io.Discard
+	logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	logger.Level = logrus.DebugLevel
+	logger.AddHook(hook)
+	return logger
+}
+
+func Test_hook_captures_entries(t *testing.T) {
+	// given a logrus logger with a memlog hook
+	hook := NewHook(10)
+	logger := newTestLogger(hook)
+
+	// when messages are logged
+	logger.Info("first")
+	logger.WithField("user", "bob").Warn("second")
+
+	// then the formatted entries are stored
+	assert.Equal(t, []string{
+		`level=info msg=first`,
+		`level=warning msg=second user=bob`,
+	}, hook.Buffer().Slice())
+}
+
+func Test_hook_is_bounded(t *testing.T) {
+	hook := NewHook(2)
+	logger := newTestLogger(hook)
+
+	logger.Debug("one")
+	logger.Debug("two")
+	logger.Error("three")
+
+	assert.Equal(t, []string{
+		`level=debug msg=two`,
+		`level=error msg=three`,
+	}, hook.Buffer().Slice())
+}
+
+func Test_hook_levels(t *testing.T) {
+	hook := NewHook(10)
+	assert.Equal(t, logrus.AllLevels, hook.Levels())
+}
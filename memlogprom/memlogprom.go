@@ -0,0 +1,55 @@
+// Package memlogprom provides a Prometheus collector that
+// exports the size and counters of a memlog.  It is separate
+// from package memlog so that only programs using it depend
+// on the Prometheus client.
+package memlogprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yabosh/memlog"
+)
+
+// collector exports the size and
+// counters of a MemLog as Prometheus metrics.
+type collector[T any] struct {
+	log            *memlog.MemLog[T]
+	currentLen     *prometheus.Desc
+	capacity       *prometheus.Desc
+	totalAppends   *prometheus.Desc
+	totalEvictions *prometheus.Desc
+}
+
+// NewCollector returns a prometheus.Collector that
+// exposes the state of log as the metrics <name>_current_len,
+// <name>_capacity, <name>_total_appends and <name>_total_evictions.
+// The collector can be registered and unregistered with any
+// prometheus.Registerer.  The totals keep counting across calls
+// to Clear, as reported by LifetimeStats, so they never
+// decrease.
+func NewCollector[T any](log *memlog.MemLog[T], name, help string) prometheus.Collector {
+	return &collector[T]{
+		log:            log,
+		currentLen:     prometheus.NewDesc(name+"_current_len", help+" (current number of entries)", nil, nil),
+		capacity:       prometheus.NewDesc(name+"_capacity", help+" (maximum number of entries)", nil, nil),
+		totalAppends:   prometheus.NewDesc(name+"_total_appends", help+" (entries appended)", nil, nil),
+		totalEvictions: prometheus.NewDesc(name+"_total_evictions", help+" (entries evicted)", nil, nil),
+	}
+}
+
+// Describe sends the descriptors of the exported metrics to ch.
+func (c *collector[T]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.currentLen
+	ch <- c.capacity
+	ch <- c.totalAppends
+	ch <- c.totalEvictions
+}
+
+// Collect sends the current metric values to ch.
+func (c *collector[T]) Collect(ch chan<- prometheus.Metric) {
+	stats := c.log.LifetimeStats()
+
+	ch <- prometheus.MustNewConstMetric(c.currentLen, prometheus.GaugeValue, float64(c.log.Len()))
+	ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(c.log.Cap()))
+	ch <- prometheus.MustNewConstMetric(c.totalAppends, prometheus.CounterValue, float64(stats.TotalAppends))
+	ch <- prometheus.MustNewConstMetric(c.totalEvictions, prometheus.CounterValue, float64(stats.TotalEvictions))
+}
@@ -0,0 +1,91 @@
+package memlogprom
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/yabosh/memlog"
+)
+
+// gatherValues returns the value of each metric gathered from reg.
+func gatherValues(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		case dto.MetricType_COUNTER:
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		}
+	}
+
+	return values
+}
+
+func Test_collector_metrics(t *testing.T) {
+	// given a registered collector for a log
+	log := memlog.NewMemLog[string](3)
+	reg := prometheus.NewRegistry()
+	assert.NoError(t, reg.Register(NewCollector(log, "app_log", "Application log")))
+
+	// then an empty log reports no activity
+	assert.Equal(t, map[string]float64{
+		"app_log_current_len":     0,
+		"app_log_capacity":        3,
+		"app_log_total_appends":   0,
+		"app_log_total_evictions": 0,
+	}, gatherValues(t, reg))
+
+	// when more entries are appended than the log holds
+	for i := 0; i < 5; i++ {
+		log.Append("entry")
+	}
+
+	// then the metrics reflect the appends and evictions
+	assert.Equal(t, map[string]float64{
+		"app_log_current_len":     3,
+		"app_log_capacity":        3,
+		"app_log_total_appends":   5,
+		"app_log_total_evictions": 2,
+	}, gatherValues(t, reg))
+}
+
+func Test_collector_unregister(t *testing.T) {
+	log := memlog.NewMemLog[int](3)
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(log, "jobs", "Job results")
+
+	assert.NoError(t, reg.Register(collector))
+	assert.Error(t, reg.Register(NewCollector(log, "jobs", "Job results")))
+	assert.True(t, reg.Unregister(collector))
+	assert.Empty(t, gatherValues(t, reg))
+}
+
+func Test_collector_counters_survive_clear(t *testing.T) {
+	// given a registered collector for a log with evictions
+	log := memlog.NewMemLog[int](2)
+	reg := prometheus.NewRegistry()
+	assert.NoError(t, reg.Register(NewCollector(log, "jobs", "Job results")))
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+	}
+
+	// when the log is cleared and appended to again
+	log.Clear()
+	log.Append(5)
+
+	// then the counters continue from their previous values
+	values := gatherValues(t, reg)
+	assert.Equal(t, float64(6), values["jobs_total_appends"])
+	assert.Equal(t, float64(3), values["jobs_total_evictions"])
+	assert.Equal(t, float64(1), values["jobs_current_len"])
+	assert.Equal(t, uint64(1), log.Stats().TotalAppends)
+}
@@ -0,0 +1,144 @@
+// Package memlogtest provides assertions for tests that
+// capture output in a memlog.
+//
+// Each assertion reports a failure with t.Errorf, including
+// the newest entries of the log for context, and returns
+// whether it passed.  Entries are compared as text using
+// fmt.Sprint.
+package memlogtest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yabosh/memlog"
+)
+
+// contextEntries is the number of entries
+// included in failure messages.
+const contextEntries = 5
+
+// pollInterval is how often EventuallyContains
+// checks the log.
+const pollInterval = 10 * time.Millisecond
+
+// Contains asserts that an entry in m contains substr.
+func Contains[T any](t testing.TB, m *memlog.MemLog[T], substr string) bool {
+	t.Helper()
+
+	if containsText(m.Slice(), substr) {
+		return true
+	}
+	t.Errorf("no entry contains %q\n%s", substr, describe(m))
+	return false
+}
+
+// NotContains asserts that no entry in m contains substr.
+func NotContains[T any](t testing.TB, m *memlog.MemLog[T], substr string) bool {
+	t.Helper()
+
+	for _, entry := range m.Slice() {
+		if text := fmt.Sprint(entry); strings.Contains(text, substr) {
+			t.Errorf("entry %q contains %q\n%s", text, substr, describe(m))
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesRegexp asserts that an entry in m matches pattern.
+func MatchesRegexp[T any](t testing.TB, m *memlog.MemLog[T], pattern string) bool {
+	t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		t.Errorf("invalid pattern %q: %v", pattern, err)
+		return false
+	}
+
+	for _, entry := range m.Slice() {
+		if re.MatchString(fmt.Sprint(entry)) {
+			return true
+		}
+	}
+	t.Errorf("no entry matches %q\n%s", pattern, describe(m))
+	return false
+}
+
+// Last asserts that the newest entry in m is want.
+func Last[T any](t testing.TB, m *memlog.MemLog[T], want T) bool {
+	t.Helper()
+
+	last := m.SliceN(1)
+	if len(last) == 0 {
+		t.Errorf("log is empty, want last entry %v", want)
+		return false
+	}
+
+	if !reflect.DeepEqual(last[0], want) {
+		t.Errorf("last entry is %v, want %v\n%s", last[0], want, describe(m))
+		return false
+	}
+	return true
+}
+
+// Empty asserts that m has no entries.
+func Empty[T any](t testing.TB, m *memlog.MemLog[T]) bool {
+	t.Helper()
+
+	if m.Len() == 0 {
+		return true
+	}
+	t.Errorf("log is not empty\n%s", describe(m))
+	return false
+}
+
+// EventuallyContains asserts that an entry containing substr
+// is appended to m within timeout, for instance by a
+// goroutine that is still running.
+func EventuallyContains[T any](t testing.TB, m *memlog.MemLog[T], substr string, timeout time.Duration) bool {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if containsText(m.Slice(), substr) {
+			return true
+		}
+
+		if !time.Now().Before(deadline) {
+			t.Errorf("no entry contains %q after %v\n%s", substr, timeout, describe(m))
+			return false
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// containsText returns true if an entry contains substr.
+func containsText[T any](entries []T, substr string) bool {
+	for _, entry := range entries {
+		if strings.Contains(fmt.Sprint(entry), substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the newest entries of m for
+// inclusion in a failure message.
+func describe[T any](m *memlog.MemLog[T]) string {
+	entries := m.SliceN(contextEntries)
+	if len(entries) == 0 {
+		return "log is empty"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "last %d of %d entries:", len(entries), m.Len())
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "\n\t%v", entry)
+	}
+	return sb.String()
+}
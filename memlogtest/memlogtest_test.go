@@ -0,0 +1,121 @@
+package memlogtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yabosh/memlog"
+)
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func newLog(lines ...string) *memlog.MemLog[string] {
+	m := memlog.NewMemLog[string](10)
+	for _, line := range lines {
+		m.Append(line)
+	}
+	return m
+}
+
+func Test_contains(t *testing.T) {
+	m := newLog("INFO starting", "ERROR disk full")
+
+	tb := &fakeTB{}
+	assert.True(t, Contains(tb, m, "disk"))
+	assert.True(t, NotContains(tb, m, "WARN"))
+	assert.Empty(t, tb.failures)
+
+	assert.False(t, Contains(tb, m, "network"))
+	assert.False(t, NotContains(tb, m, "ERROR"))
+	assert.Len(t, tb.failures, 2)
+	assert.Contains(t, tb.failures[0], `no entry contains "network"`)
+	assert.Contains(t, tb.failures[0], "last 2 of 2 entries:\n\tINFO starting\n\tERROR disk full")
+}
+
+func Test_matches_regexp(t *testing.T) {
+	m := newLog("request took 15ms")
+
+	tb := &fakeTB{}
+	assert.True(t, MatchesRegexp(tb, m, `took \d+ms`))
+	assert.Empty(t, tb.failures)
+
+	assert.False(t, MatchesRegexp(tb, m, `took \d+s$`))
+	assert.False(t, MatchesRegexp(tb, m, `(`))
+	assert.Len(t, tb.failures, 2)
+	assert.Contains(t, tb.failures[1], "invalid pattern")
+}
+
+func Test_last(t *testing.T) {
+	m := newLog("a", "b")
+
+	tb := &fakeTB{}
+	assert.True(t, Last(tb, m, "b"))
+	assert.Empty(t, tb.failures)
+
+	assert.False(t, Last(tb, m, "a"))
+	assert.False(t, Last(tb, newLog(), "a"))
+	assert.Len(t, tb.failures, 2)
+	assert.Contains(t, tb.failures[0], "last entry is b, want a")
+	assert.Contains(t, tb.failures[1], "log is empty")
+}
+
+func Test_empty(t *testing.T) {
+	tb := &fakeTB{}
+	assert.True(t, Empty(tb, newLog()))
+	assert.False(t, Empty(tb, newLog("a")))
+	assert.Len(t, tb.failures, 1)
+}
+
+func Test_failure_shows_newest_entries(t *testing.T) {
+	m := newLog("1", "2", "3", "4", "5", "6", "7")
+
+	tb := &fakeTB{}
+	Contains(tb, m, "missing")
+
+	assert.Contains(t, tb.failures[0], "last 5 of 7 entries:\n\t3\n\t4\n\t5\n\t6\n\t7")
+}
+
+func Test_eventually_contains(t *testing.T) {
+	// given a producer that appends after a delay
+	m := newLog()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		m.Append("ready")
+	}()
+
+	// when waiting for the entry
+	tb := &fakeTB{}
+	ok := EventuallyContains(tb, m, "ready", time.Second)
+
+	// then the assertion passes
+	assert.True(t, ok)
+	assert.Empty(t, tb.failures)
+}
+
+func Test_eventually_contains_timeout(t *testing.T) {
+	// given a log that never receives the entry
+	m := newLog("waiting")
+
+	// when waiting for the entry
+	tb := &fakeTB{}
+	start := time.Now()
+	ok := EventuallyContains(tb, m, "ready", 30*time.Millisecond)
+
+	// then the assertion fails after the timeout
+	assert.False(t, ok)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Len(t, tb.failures, 1)
+	assert.Contains(t, tb.failures[0], `no entry contains "ready" after 30ms`)
+}
@@ -0,0 +1,113 @@
+// Package memlogws provides an http.Handler that streams the
+// entries of a memlog over a WebSocket connection.  It is
+// separate from package memlog so that only programs using it
+// depend on gorilla/websocket.
+package memlogws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yabosh/memlog"
+)
+
+const (
+	// subscriptionBuffer is the number of entries that may
+	// be queued for a client before new entries are dropped.
+	subscriptionBuffer = 256
+
+	// writeWait is the time allowed to write a message.
+	writeWait = 10 * time.Second
+
+	// pongWait is the time allowed to receive a pong
+	// before the client is considered disconnected.
+	pongWait = 60 * time.Second
+
+	// pingPeriod is how often pings are sent.  It must
+	// be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+)
+
+// handler streams the contents of a MemLog
+// over a WebSocket connection.
+type handler[T any] struct {
+	log      *memlog.MemLog[T]
+	upgrader *websocket.Upgrader
+}
+
+// NewHandler returns an http.Handler that upgrades the
+// request to a WebSocket connection using upgrader, or a default
+// upgrader if it is nil.  The current contents of log are sent
+// as a single JSON array, followed by each new entry as an
+// individual JSON message, until the client disconnects.
+func NewHandler[T any](log *memlog.MemLog[T], upgrader *websocket.Upgrader) http.Handler {
+	if upgrader == nil {
+		upgrader = &websocket.Upgrader{}
+	}
+
+	return &handler[T]{
+		log:      log,
+		upgrader: upgrader,
+	}
+}
+
+// ServeHTTP streams log entries to the client.
+func (h *handler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		return
+	}
+	defer conn.Close()
+
+	backlog, entries, cancel := h.log.SubscribeWithBacklog(subscriptionBuffer)
+	defer cancel()
+
+	closed := make(chan struct{})
+	go readUntilClosed(conn, closed)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := conn.WriteJSON(backlog); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-r.Context().Done():
+			return
+		case entry := <-entries:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(entry); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readUntilClosed processes control messages from the client,
+// such as pongs and close frames, discarding any data messages.
+// closed is closed when the connection fails or is closed.
+func readUntilClosed(conn *websocket.Conn, closed chan<- struct{}) {
+	defer close(closed)
+
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
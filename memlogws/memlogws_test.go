@@ -0,0 +1,105 @@
+package memlogws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/yabosh/memlog"
+)
+
+func dialTestServer(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	return conn
+}
+
+func Test_handler_streams_backlog_and_new_entries(t *testing.T) {
+	// given a log with existing entries served over a websocket
+	log := memlog.NewMemLog[string](10)
+	log.Append("item #1")
+	log.Append("item #2")
+
+	server := httptest.NewServer(NewHandler(log, nil))
+	defer server.Close()
+
+	// when a client connects
+	conn := dialTestServer(t, server)
+	defer conn.Close()
+
+	// then the backlog is sent as an array
+	var backlog []string
+	assert.NoError(t, conn.ReadJSON(&backlog))
+	assert.Equal(t, []string{"item #1", "item #2"}, backlog)
+
+	// and new entries are sent individually
+	log.Append("item #3")
+	var entry string
+	assert.NoError(t, conn.ReadJSON(&entry))
+	assert.Equal(t, "item #3", entry)
+}
+
+func Test_handler_unsubscribes_on_disconnect(t *testing.T) {
+	// given a connected client
+	log := memlog.NewMemLog[int](10)
+	server := httptest.NewServer(NewHandler(log, nil))
+	defer server.Close()
+
+	conn := dialTestServer(t, server)
+	var backlog []int
+	assert.NoError(t, conn.ReadJSON(&backlog))
+	assert.Empty(t, backlog)
+
+	// when the client closes the connection
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	conn.Close()
+
+	// then the subscription is removed
+	assert.Eventually(t, func() bool {
+		return log.Subscribers() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_handler_answers_ping(t *testing.T) {
+	// given a connected client
+	log := memlog.NewMemLog[int](10)
+	server := httptest.NewServer(NewHandler(log, nil))
+	defer server.Close()
+
+	conn := dialTestServer(t, server)
+	defer conn.Close()
+
+	pong := make(chan string, 1)
+	conn.SetPongHandler(func(data string) error {
+		pong <- data
+		return nil
+	})
+
+	// when the client sends a ping
+	assert.NoError(t, conn.WriteControl(websocket.PingMessage, []byte("hello"), time.Now().Add(time.Second)))
+
+	// then the server replies with a pong
+	var backlog []int
+	assert.NoError(t, conn.ReadJSON(&backlog))
+	go conn.ReadMessage()
+	select {
+	case data := <-pong:
+		assert.Equal(t, "hello", data)
+	case <-time.After(time.Second):
+		t.Fatal("no pong received")
+	}
+}
+
+func Test_handler_rejects_plain_http(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(memlog.NewMemLog[int](10), nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
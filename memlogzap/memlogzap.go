@@ -0,0 +1,75 @@
+// Package memlogzap provides a zapcore.Core that stores
+// encoded log entries in a memlog.  It is separate from package
+// memlog so that only programs using it depend on zap.
+package memlogzap
+
+import (
+	"strings"
+
+	"github.com/yabosh/memlog"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core is a zapcore.Core that encodes each log entry
+// and stores the result in a MemLog.
+type Core struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	buffer *memlog.MemLog[string]
+}
+
+// NewCore returns a Core that encodes entries at all
+// levels with enc and keeps at most size entries.
+func NewCore(size int, enc zapcore.Encoder) *Core {
+	return &Core{
+		LevelEnabler: zapcore.DebugLevel,
+		enc:          enc,
+		buffer:       memlog.NewMemLog[string](size),
+	}
+}
+
+// Buffer returns the MemLog holding the encoded entries.
+func (c *Core) Buffer() *memlog.MemLog[string] {
+	return c.buffer
+}
+
+// With returns a new Core, sharing the same buffer,
+// that adds fields to every entry.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	return &Core{
+		LevelEnabler: c.LevelEnabler,
+		enc:          enc,
+		buffer:       c.buffer,
+	}
+}
+
+// Check adds the core to ce if ent's level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write encodes ent and fields and appends the
+// result to the buffer.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+
+	c.buffer.Append(strings.TrimRight(buf.String(), "\r\n"))
+	buf.Free()
+	return nil
+}
+
+// Sync has nothing to flush and always returns nil.
+func (c *Core) Sync() error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package memlogzap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+}
+
+func Test_core_captures_entries(t *testing.T) {
+	// given a zap logger backed by a memlog core
+	core := NewCore(10, newTestEncoder())
+	logger := zap.New(core)
+
+	// when messages are logged
+	logger.Debug("first")
+	logger.Warn("second", zap.Int("count", 2))
+
+	// then the encoded entries are stored
+	assert.Equal(t, []string{
+		`{"level":"debug","msg":"first"}`,
+		`{"level":"warn","msg":"second","count":2}`,
+	}, core.Buffer().Slice())
+}
+
+func Test_core_with_fields(t *testing.T) {
+	// given a logger with fields
+	core := NewCore(10, newTestEncoder())
+	logger := zap.New(core)
+	child := logger.With(zap.String("service", "api"))
+
+	// when messages are logged by both loggers
+	child.Info("from child")
+	logger.Info("from parent")
+
+	// then fields are only added by the child, and
+	// both share the same buffer
+	assert.Equal(t, []string{
+		`{"level":"info","msg":"from child","service":"api"}`,
+		`{"level":"info","msg":"from parent"}`,
+	}, core.Buffer().Slice())
+}
+
+func Test_core_is_bounded(t *testing.T) {
+	core := NewCore(2, newTestEncoder())
+	logger := zap.New(core)
+
+	logger.Info("one")
+	logger.Info("two")
+	logger.Error("three")
+
+	assert.Equal(t, []string{
+		`{"level":"info","msg":"two"}`,
+		`{"level":"error","msg":"three"}`,
+	}, core.Buffer().Slice())
+	assert.NoError(t, logger.Sync())
+}
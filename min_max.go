@@ -0,0 +1,62 @@
+package memlog
+
+import "cmp"
+
+// Min returns the smallest entry in log according to less,
+// or false if the log is empty.  When several entries are
+// equally small the oldest is returned.
+func Min[T any](log *MemLog[T], less func(T, T) bool) (min T, ok bool) {
+	return minN(log, allElements, less)
+}
+
+// Max returns the largest entry in log according to less,
+// or false if the log is empty.  When several entries are
+// equally large the oldest is returned.
+func Max[T any](log *MemLog[T], less func(T, T) bool) (max T, ok bool) {
+	return Min(log, func(a, b T) bool {
+		return less(b, a)
+	})
+}
+
+// MinFunc is like Min for logs of ordered types
+// such as numbers and strings.
+func MinFunc[T cmp.Ordered](log *MemLog[T]) (T, bool) {
+	return Min(log, cmp.Less[T])
+}
+
+// MaxFunc is like Max for logs of ordered types
+// such as numbers and strings.
+func MaxFunc[T cmp.Ordered](log *MemLog[T]) (T, bool) {
+	return Max(log, cmp.Less[T])
+}
+
+// MinN is like MinFunc but only considers the last n
+// entries in the log.  If n is negative every entry
+// is considered.
+func MinN[T cmp.Ordered](log *MemLog[T], n int) (T, bool) {
+	return minN(log, n, cmp.Less[T])
+}
+
+// MaxN is like MaxFunc but only considers the last n
+// entries in the log.  If n is negative every entry
+// is considered.
+func MaxN[T cmp.Ordered](log *MemLog[T], n int) (T, bool) {
+	return minN(log, n, func(a, b T) bool {
+		return cmp.Less(b, a)
+	})
+}
+
+// minN returns the smallest of the last n entries in
+// log according to less, or false if there are none.
+func minN[T any](log *MemLog[T], n int, less func(T, T) bool) (min T, ok bool) {
+	log.rlock()
+	defer log.runlock()
+
+	log.forEachN(n, func(item T) {
+		if !ok || less(item, min) {
+			min, ok = item, true
+		}
+	})
+
+	return min, ok
+}
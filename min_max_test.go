@@ -0,0 +1,111 @@
+package memlog
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_min_max_ints(t *testing.T) {
+	// given a log of integers
+	log := NewMemLog[int](10)
+	for _, v := range []int{4, -2, 9, 0, 9} {
+		log.Append(v)
+	}
+
+	// then the smallest and largest entries are returned
+	min, ok := MinFunc(log)
+	assert.True(t, ok)
+	assert.Equal(t, -2, min)
+
+	max, ok := MaxFunc(log)
+	assert.True(t, ok)
+	assert.Equal(t, 9, max)
+}
+
+func Test_min_max_strings(t *testing.T) {
+	log := NewMemLog[string](10)
+	for _, v := range []string{"pear", "apple", "zucchini", "fig"} {
+		log.Append(v)
+	}
+
+	min, _ := MinFunc(log)
+	max, _ := MaxFunc(log)
+	assert.Equal(t, "apple", min)
+	assert.Equal(t, "zucchini", max)
+
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	shortest, _ := Min(log, byLength)
+	longest, _ := Max(log, byLength)
+	assert.Equal(t, "fig", shortest)
+	assert.Equal(t, "zucchini", longest)
+}
+
+func Test_min_max_empty(t *testing.T) {
+	log := NewMemLog[int](10)
+
+	_, ok := MinFunc(log)
+	assert.False(t, ok)
+	_, ok = Max(log, func(a, b int) bool { return a < b })
+	assert.False(t, ok)
+}
+
+func Test_min_max_n(t *testing.T) {
+	// given a log whose extremes are among the oldest entries
+	log := NewMemLog[int64](10)
+	for _, v := range []int64{-50, 90, 3, 7, 5} {
+		log.Append(v)
+	}
+
+	tests := []struct {
+		n        int
+		min, max int64
+		ok       bool
+	}{
+		{0, 0, 0, false},
+		{1, 5, 5, true},
+		{3, 3, 7, true},
+		{5, -50, 90, true},
+		{10, -50, 90, true},
+		{-1, -50, 90, true},
+	}
+
+	for _, tt := range tests {
+		min, ok := MinN(log, tt.n)
+		assert.Equal(t, tt.ok, ok, "n=%d", tt.n)
+		assert.Equal(t, tt.min, min, "n=%d", tt.n)
+
+		max, ok := MaxN(log, tt.n)
+		assert.Equal(t, tt.ok, ok, "n=%d", tt.n)
+		assert.Equal(t, tt.max, max, "n=%d", tt.n)
+	}
+}
+
+func Test_min_max_n_floats(t *testing.T) {
+	log := NewMemLog[float64](10)
+	log.Append(-1.5)
+	log.Append(2.25)
+	log.Append(0.5)
+
+	min, _ := MinN(log, 2)
+	max, _ := MaxN(log, 2)
+	assert.Equal(t, 0.5, min)
+	assert.Equal(t, 2.25, max)
+
+	// NaN orders before every other value
+	log.Append(math.NaN())
+	min, _ = MinN(log, 2)
+	max, _ = MaxN(log, 2)
+	assert.True(t, math.IsNaN(min))
+	assert.Equal(t, 0.5, max)
+}
+
+func Test_min_max_n_empty(t *testing.T) {
+	log := NewMemLog[float64](10)
+
+	_, ok := MinN(log, 5)
+	assert.False(t, ok)
+	_, ok = MaxN(log, -1)
+	assert.False(t, ok)
+}
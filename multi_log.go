@@ -0,0 +1,24 @@
+package memlog
+
+// MultiLog appends each entry to a set of MemLog
+// instances.  This is useful for routing the same entry
+// to a short "recent" log and a longer "history" log.
+//
+// MultiLog is thread-safe
+type MultiLog[T any] struct {
+	logs []*MemLog[T]
+}
+
+// NewMultiLog returns a MultiLog that appends
+// to each of logs.
+func NewMultiLog[T any](logs ...*MemLog[T]) *MultiLog[T] {
+	return &MultiLog[T]{logs: logs}
+}
+
+// Append adds item to each of the logs.  Each log is
+// locked only while item is being added to it.
+func (m *MultiLog[T]) Append(item T) {
+	for _, log := range m.logs {
+		log.Append(item)
+	}
+}
@@ -0,0 +1,29 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_multi_log_appends_to_all_logs(t *testing.T) {
+	// given a short recent log and a longer history log
+	recent := NewMemLog[int](2)
+	history := NewMemLog[int](10)
+	log := NewMultiLog(recent, history)
+
+	// when entries are appended
+	for i := 1; i <= 4; i++ {
+		log.Append(i)
+	}
+
+	// then each log receives every entry up to its size
+	assert.Equal(t, []int{3, 4}, recent.Slice())
+	assert.Equal(t, []int{1, 2, 3, 4}, history.Slice())
+}
+
+func Test_multi_log_without_logs(t *testing.T) {
+	log := NewMultiLog[int]()
+
+	assert.NotPanics(t, func() { log.Append(1) })
+}
@@ -0,0 +1,55 @@
+package memlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriteNDJSON writes the contents of the log to w as
+// newline-delimited JSON, one entry per line ordered from
+// oldest to newest.  Each entry is encoded and written
+// separately from a snapshot of the log.
+func (m *MemLog[T]) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, item := range m.Slice() {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendNDJSON reads newline-delimited JSON from r until EOF,
+// decoding each line into an entry that is appended to the
+// log.  Blank lines are skipped.  Lines are not limited in
+// length.  It returns the number of entries appended and, if
+// a line cannot be decoded, an error that includes its line
+// number.  Entries decoded before the error are kept.
+func (m *MemLog[T]) AppendNDJSON(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	appended := 0
+
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return appended, err
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var item T
+			if err := json.Unmarshal(line, &item); err != nil {
+				return appended, fmt.Errorf("memlog: line %d: %w", lineNo, err)
+			}
+			m.Append(item)
+			appended++
+		}
+
+		if err != nil {
+			return appended, nil
+		}
+	}
+}
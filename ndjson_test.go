@@ -0,0 +1,90 @@
+package memlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ndjsonEntry struct {
+	Time    time.Time         `json:"time"`
+	Level   string            `json:"level"`
+	Message string            `json:"msg"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func Test_memlog_ndjson_round_trip(t *testing.T) {
+	// given a log of structs
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	log := NewMemLog[ndjsonEntry](10)
+	log.Append(ndjsonEntry{Time: start, Level: "info", Message: "started"})
+	log.Append(ndjsonEntry{Time: start.Add(time.Second), Level: "error", Message: "line\nbreak", Fields: map[string]string{"id": "7"}})
+
+	// when it is written as NDJSON and read into another log
+	var buf bytes.Buffer
+	assert.NoError(t, log.WriteNDJSON(&buf))
+
+	copied := NewMemLog[ndjsonEntry](10)
+	n, err := copied.AppendNDJSON(&buf)
+
+	// then each entry is one line and is restored unchanged
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, log.Slice(), copied.Slice())
+}
+
+func Test_memlog_write_ndjson_lines(t *testing.T) {
+	log := NewMemLog[ndjsonEntry](10)
+	log.Append(ndjsonEntry{Level: "info", Message: "a"})
+	log.Append(ndjsonEntry{Level: "warn", Message: "b"})
+
+	var buf bytes.Buffer
+	assert.NoError(t, log.WriteNDJSON(&buf))
+
+	assert.Equal(t,
+		`{"time":"0001-01-01T00:00:00Z","level":"info","msg":"a"}`+"\n"+
+			`{"time":"0001-01-01T00:00:00Z","level":"warn","msg":"b"}`+"\n",
+		buf.String())
+}
+
+func Test_memlog_append_ndjson_skips_blank_lines(t *testing.T) {
+	log := NewMemLog[int](10)
+
+	n, err := log.AppendNDJSON(strings.NewReader("1\n\n  \r\n2\n3"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []int{1, 2, 3}, log.Slice())
+}
+
+func Test_memlog_append_ndjson_malformed_line(t *testing.T) {
+	// given input with a malformed third line
+	log := NewMemLog[ndjsonEntry](10)
+	input := `{"msg":"a"}` + "\n\n" + `{"msg":` + "\n" + `{"msg":"c"}` + "\n"
+
+	// when it is read
+	n, err := log.AppendNDJSON(strings.NewReader(input))
+
+	// then the error reports the line and earlier entries are kept
+	assert.Equal(t, 1, n)
+	assert.ErrorContains(t, err, "memlog: line 3:")
+	assert.Equal(t, 1, log.Len())
+}
+
+func Test_memlog_append_ndjson_long_line(t *testing.T) {
+	// given a line far longer than bufio.Scanner's default limit
+	message := strings.Repeat("x", 4<<20)
+	input := `{"msg":"` + message + `"}` + "\n"
+
+	// when it is read
+	log := NewMemLog[ndjsonEntry](10)
+	n, err := log.AppendNDJSON(strings.NewReader(input))
+
+	// then it is decoded
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, log.Slice()[0].Message, 4<<20)
+}
@@ -0,0 +1,45 @@
+package memlog
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// PanicRecord is a panic recovered by RecoverInto.
+type PanicRecord struct {
+	Time  time.Time
+	Value string
+	Stack []byte
+}
+
+// RecoverInto recovers from a panic and records it in m,
+// along with the stack of the panicking goroutine.  It must
+// be deferred directly:
+//
+//	defer memlog.RecoverInto(panics)
+//
+// RecoverInto does nothing if the goroutine is not panicking.
+func RecoverInto(m *MemLog[PanicRecord]) {
+	if r := recover(); r != nil {
+		recordPanic(m, r)
+	}
+}
+
+// RecoverIntoAndRethrow is like RecoverInto but panics again
+// with the recovered value once it has been recorded.
+func RecoverIntoAndRethrow(m *MemLog[PanicRecord]) {
+	if r := recover(); r != nil {
+		recordPanic(m, r)
+		panic(r)
+	}
+}
+
+// recordPanic adds the panic value r to m.
+func recordPanic(m *MemLog[PanicRecord], r any) {
+	m.Append(PanicRecord{
+		Time:  m.now(),
+		Value: fmt.Sprint(r),
+		Stack: debug.Stack(),
+	})
+}
@@ -0,0 +1,80 @@
+package memlog
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func panickingWorker(panics *MemLog[PanicRecord], value any) {
+	defer RecoverInto(panics)
+	panic(value)
+}
+
+func Test_recover_into_records_panics(t *testing.T) {
+	// given a log of panics with a fake clock
+	clock := NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	panics := NewMemLog(10, WithClock[PanicRecord](clock))
+
+	// when workers panic in their own goroutines
+	var wg sync.WaitGroup
+	for _, value := range []any{"boom", errors.New("bad state")} {
+		wg.Add(1)
+		go func(value any) {
+			defer wg.Done()
+			panickingWorker(panics, value)
+		}(value)
+	}
+	wg.Wait()
+
+	// then each panic is recorded with its value and stack
+	records := panics.Slice()
+	assert.Len(t, records, 2)
+
+	var values []string
+	for _, record := range records {
+		values = append(values, record.Value)
+		assert.Equal(t, clock.Now(), record.Time)
+		assert.Contains(t, string(record.Stack), "memlog.panickingWorker")
+	}
+	assert.ElementsMatch(t, []string{"boom", "bad state"}, values)
+}
+
+func Test_recover_into_and_rethrow(t *testing.T) {
+	// given a worker that records and rethrows panics
+	panics := NewMemLog[PanicRecord](10)
+	worker := func() {
+		defer RecoverIntoAndRethrow(panics)
+		panic("fatal")
+	}
+
+	// when it panics
+	rethrown := make(chan any)
+	go func() {
+		defer func() { rethrown <- recover() }()
+		worker()
+	}()
+
+	// then the panic is recorded and continues
+	assert.Equal(t, "fatal", <-rethrown)
+	assert.Len(t, panics.Slice(), 1)
+	assert.Equal(t, "fatal", panics.Slice()[0].Value)
+}
+
+func Test_recover_into_without_panic(t *testing.T) {
+	// given a function that does not panic
+	panics := NewMemLog[PanicRecord](10)
+	work := func() {
+		defer RecoverInto(panics)
+	}
+
+	// when it runs
+	allocs := testing.AllocsPerRun(100, work)
+
+	// then nothing is recorded or allocated
+	assert.Zero(t, allocs)
+	assert.Equal(t, 0, panics.Len())
+}
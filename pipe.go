@@ -0,0 +1,30 @@
+package memlog
+
+// pipeBuffer is the number of entries that may be queued
+// for forwarding before new entries are dropped.
+const pipeBuffer = 256
+
+// Pipe forwards each entry appended to src after Pipe returns
+// to dst, and returns a function that stops forwarding.  Entries
+// already in src are not copied.
+//
+// Pipe is useful for fan-out where a primary log feeds several
+// smaller, specialized logs.  As with Subscribe, entries are
+// dropped if dst falls too far behind src.  Once the stop
+// function returns no further entries are forwarded.
+func Pipe[T any](src, dst *MemLog[T]) func() {
+	ch, cancel := src.Subscribe(pipeBuffer)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for item := range ch {
+			dst.Append(item)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
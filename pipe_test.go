@@ -0,0 +1,54 @@
+package memlog
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pipe_forwards_new_entries(t *testing.T) {
+	// given a source log with existing entries
+	src := NewMemLog[int](100)
+	dst := NewMemLog[int](100)
+	src.Append(-1)
+
+	// when entries are appended concurrently after piping
+	stop := Pipe(src, dst)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				src.Append(g*10 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// then they appear in the destination but existing entries do not
+	assert.Eventually(t, func() bool { return dst.Len() == 40 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, src.SliceN(40), dst.Slice())
+
+	stop()
+}
+
+func Test_pipe_stop(t *testing.T) {
+	// given a pipe that has been stopped
+	src := NewMemLog[int](10)
+	dst := NewMemLog[int](10)
+	stop := Pipe(src, dst)
+	src.Append(1)
+	assert.Eventually(t, func() bool { return dst.Len() == 1 }, time.Second, time.Millisecond)
+	stop()
+
+	// when more entries are appended to the source
+	src.Append(2)
+	time.Sleep(10 * time.Millisecond)
+
+	// then they are not forwarded
+	assert.Equal(t, []int{1}, dst.Slice())
+	stop()
+}
@@ -0,0 +1,117 @@
+package memlog
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// priorityHeap is a max-heap ordered by less.
+type priorityHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.items) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *priorityHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *priorityHeap[T]) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+	var zero T
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return item
+}
+
+// lowest returns the index of the lowest priority item.
+// It is always a leaf so only the second half of the
+// heap is searched.  The heap must not be empty.
+func (h *priorityHeap[T]) lowest() int {
+	min := len(h.items) / 2
+	for i := min + 1; i < len(h.items); i++ {
+		if h.less(h.items[i], h.items[min]) {
+			min = i
+		}
+	}
+	return min
+}
+
+// PriorityLog is a bounded log that retains the highest
+// priority entries rather than the newest.  Priority is
+// determined by less, which reports whether a has a lower
+// priority than b.  When the log is full the lowest
+// priority entry is removed to make room.
+//
+// PriorityLog is thread-safe
+type PriorityLog[T any] struct {
+	size   int
+	heap   priorityHeap[T]
+	locker sync.Mutex
+}
+
+// NewPriorityLog returns a new PriorityLog that will not
+// grow beyond size entries.
+func NewPriorityLog[T any](size int, less func(a, b T) bool) *PriorityLog[T] {
+	return &PriorityLog[T]{
+		size: size,
+		heap: priorityHeap[T]{less: less},
+	}
+}
+
+// Append adds v to the log.  If the log is full the lowest
+// priority entry is removed, which is v itself if v has a
+// lower priority than every entry in the log.
+func (p *PriorityLog[T]) Append(v T) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	if p.size <= 0 {
+		return
+	}
+
+	if p.heap.Len() >= p.size {
+		lowest := p.heap.lowest()
+		if p.heap.less(v, p.heap.items[lowest]) {
+			return
+		}
+		heap.Remove(&p.heap, lowest)
+	}
+
+	heap.Push(&p.heap, v)
+}
+
+// Pop removes and returns the highest priority entry.  The
+// second result is false if the log is empty.
+func (p *PriorityLog[T]) Pop() (item T, ok bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	if p.heap.Len() == 0 {
+		return item, false
+	}
+	return heap.Pop(&p.heap).(T), true
+}
+
+// Len returns the number of entries in the log.
+func (p *PriorityLog[T]) Len() int {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.heap.Len()
+}
+
+// Slice returns the entries in the log ordered from the
+// highest priority to the lowest.
+func (p *PriorityLog[T]) Slice() []T {
+	p.locker.Lock()
+	slice := make([]T, len(p.heap.items))
+	copy(slice, p.heap.items)
+	p.locker.Unlock()
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		return p.heap.less(slice[j], slice[i])
+	})
+	return slice
+}
@@ -0,0 +1,81 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_priority_log_int_priority(t *testing.T) {
+	// given a priority log of integers
+	log := NewPriorityLog(3, func(a, b int) bool { return a < b })
+
+	// when more entries than its size are appended
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		log.Append(v)
+	}
+
+	// then the highest priority entries are kept in priority order
+	assert.Equal(t, 3, log.Len())
+	assert.Equal(t, []int{9, 7, 5}, log.Slice())
+
+	// and an entry below every retained entry is discarded
+	log.Append(2)
+	assert.Equal(t, []int{9, 7, 5}, log.Slice())
+}
+
+func Test_priority_log_string_priority(t *testing.T) {
+	log := NewPriorityLog(2, func(a, b string) bool { return a < b })
+
+	log.Append("banana")
+	log.Append("cherry")
+	log.Append("apple")
+
+	assert.Equal(t, []string{"cherry", "banana"}, log.Slice())
+}
+
+func Test_priority_log_struct_priority(t *testing.T) {
+	// given entries ordered by severity and then by time
+	type alert struct {
+		severity int
+		seq      int
+	}
+	log := NewPriorityLog(3, func(a, b alert) bool {
+		if a.severity != b.severity {
+			return a.severity < b.severity
+		}
+		return a.seq > b.seq
+	})
+
+	// when alerts are appended
+	log.Append(alert{severity: 1, seq: 1})
+	log.Append(alert{severity: 3, seq: 2})
+	log.Append(alert{severity: 3, seq: 3})
+	log.Append(alert{severity: 2, seq: 4})
+	log.Append(alert{severity: 1, seq: 5})
+
+	// then the most severe, earliest alerts are kept
+	assert.Equal(t, []alert{{3, 2}, {3, 3}, {2, 4}}, log.Slice())
+}
+
+func Test_priority_log_pop(t *testing.T) {
+	// given a priority log with entries
+	log := NewPriorityLog(5, func(a, b int) bool { return a < b })
+	for _, v := range []int{4, 8, 2} {
+		log.Append(v)
+	}
+
+	// when entries are popped
+	var popped []int
+	for {
+		v, ok := log.Pop()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+
+	// then they are returned highest priority first
+	assert.Equal(t, []int{8, 4, 2}, popped)
+	assert.Equal(t, 0, log.Len())
+}
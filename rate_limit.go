@@ -0,0 +1,97 @@
+package memlog
+
+import (
+	"math/bits"
+	"time"
+)
+
+// tokenBucket limits the rate of appends to a log.  Partial
+// tokens are counted in units of 1/interval of a token so that
+// refilling is exact: each nanosecond adds n units.  The refill
+// is computed in 128 bits so that large rates, bursts and
+// intervals cannot overflow.
+type tokenBucket struct {
+	n        int64
+	interval int64
+	burst    int64
+	tokens   int64
+	units    int64
+	last     time.Time
+}
+
+// allow reports whether an append at now is within the
+// limit, consuming a token if it is.
+func (b *tokenBucket) allow(now time.Time) bool {
+	if b.interval <= 0 {
+		return true
+	}
+
+	if b.last.IsZero() || now.After(b.last) {
+		if !b.last.IsZero() {
+			b.refill(now.Sub(b.last))
+		}
+		// a clock that moves backwards neither adds
+		// nor removes tokens
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// refill adds the tokens earned over elapsed,
+// up to the burst size.
+func (b *tokenBucket) refill(elapsed time.Duration) {
+	if b.n <= 0 || b.tokens >= b.burst {
+		return
+	}
+
+	interval := uint64(b.interval)
+	hi, lo := bits.Mul64(uint64(elapsed), uint64(b.n))
+	lo, carry := bits.Add64(lo, uint64(b.units), 0)
+	hi += carry
+
+	// the quotient only fits in 64 bits when hi < interval,
+	// and is then compared with the tokens still missing
+	if hi < interval {
+		tokens, units := bits.Div64(hi, lo, interval)
+		if tokens < uint64(b.burst-b.tokens) {
+			b.tokens += int64(tokens)
+			b.units = int64(units)
+			return
+		}
+	}
+
+	b.tokens = b.burst
+	b.units = 0
+}
+
+// WithRateLimit limits appends to n entries per interval on
+// average, allowing bursts of up to burst entries.  Entries
+// appended beyond the limit are dropped and counted in Stats.
+// This prevents a tight loop from evicting the history that
+// led up to it.
+func WithRateLimit[T any](n int, interval time.Duration, burst int) Option[T] {
+	return func(m *MemLog[T]) {
+		m.limiter = &tokenBucket{
+			n:        int64(n),
+			interval: int64(interval),
+			burst:    int64(burst),
+			tokens:   int64(burst),
+		}
+	}
+}
+
+// WithSuppressionRecord causes a record created by fn to be
+// appended before the first entry accepted after entries were
+// dropped by WithRateLimit.  fn is passed the number of
+// entries that were dropped.
+func WithSuppressionRecord[T any](fn func(n uint64) T) Option[T] {
+	return func(m *MemLog[T]) {
+		m.suppress = fn
+	}
+}
@@ -0,0 +1,112 @@
+package memlog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memlog_rate_limit_burst(t *testing.T) {
+	// given a log limited to 10 entries per second with a burst of 3
+	clock := NewFakeClock(time.Unix(0, 0))
+	log := NewMemLog(100, WithRateLimit[int](10, time.Second, 3), WithClock[int](clock))
+
+	// when a burst of entries is appended at once
+	for i := 0; i < 10; i++ {
+		log.Append(i)
+	}
+
+	// then only the burst is kept and the rest are counted
+	assert.Equal(t, []int{0, 1, 2}, log.Slice())
+	assert.Equal(t, uint64(7), log.Stats().TotalRateLimited)
+	assert.ErrorIs(t, log.AppendErr(10), ErrRateLimited)
+}
+
+func Test_memlog_rate_limit_steady_state(t *testing.T) {
+	// given a log limited to 10 entries per second
+	clock := NewFakeClock(time.Unix(0, 0))
+	log := NewMemLog(1000, WithRateLimit[int](10, time.Second, 1), WithClock[int](clock))
+
+	// when entries are appended every 10ms for 10 seconds
+	for i := 0; i < 1000; i++ {
+		log.Append(i)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	// then about 10 entries per second are kept
+	assert.InDelta(t, 100, log.Len(), 1)
+	assert.InDelta(t, 900, log.Stats().TotalRateLimited, 1)
+}
+
+func Test_memlog_rate_limit_suppression_record(t *testing.T) {
+	// given a rate limited log with a suppression record
+	clock := NewFakeClock(time.Unix(0, 0))
+	log := NewMemLog(100,
+		WithRateLimit[string](1, time.Second, 1),
+		WithSuppressionRecord(func(n uint64) string {
+			return fmt.Sprintf("… %d entries suppressed", n)
+		}),
+		WithClock[string](clock),
+	)
+
+	// when a storm of entries subsides
+	for i := 0; i < 5; i++ {
+		log.Append("retrying")
+	}
+	clock.Advance(time.Second)
+	log.Append("recovered")
+
+	// then a record of the dropped entries precedes the next entry
+	assert.Equal(t, []string{
+		"retrying",
+		"… 4 entries suppressed",
+		"recovered",
+	}, log.Slice())
+	assert.Equal(t, uint64(4), log.Stats().TotalRateLimited)
+}
+
+func Test_memlog_rate_limit_large_burst_and_interval(t *testing.T) {
+	// given a limit whose burst and interval overflow int64 when multiplied
+	start := time.Unix(0, 0)
+	log := NewMemLog[int](10, WithRateLimit[int](10_000_000, time.Hour, 10_000_000))
+	bucket := log.limiter
+
+	// when the burst is used up
+	assert.True(t, bucket.allow(start))
+	bucket.tokens = 0
+
+	// then tokens are refilled at the configured rate
+	assert.False(t, bucket.allow(start.Add(359*time.Microsecond)))
+	assert.True(t, bucket.allow(start.Add(360*time.Microsecond)))
+	assert.False(t, bucket.allow(start.Add(360*time.Microsecond)))
+
+	bucket.allow(start.Add(30*time.Minute + 360*time.Microsecond))
+	assert.Equal(t, int64(5_000_000-1), bucket.tokens)
+
+	// and never beyond the burst, however long the log is idle
+	bucket.allow(start.Add(100 * 365 * 24 * time.Hour))
+	assert.Equal(t, int64(10_000_000-1), bucket.tokens)
+}
+
+func Test_memlog_rate_limit_clock_moves_backwards(t *testing.T) {
+	// given a rate limited log with one token left
+	clock := NewFakeClock(time.Unix(1000, 0))
+	log := NewMemLog(10, WithRateLimit[int](1, time.Second, 2), WithClock[int](clock))
+	log.Append(1)
+
+	// when the clock moves backwards
+	clock.Advance(-time.Hour)
+
+	// then the remaining token is still available
+	assert.NoError(t, log.AppendErr(2))
+	assert.ErrorIs(t, log.AppendErr(3), ErrRateLimited)
+
+	// and tokens are refilled once the clock passes its previous time
+	clock.Advance(time.Hour)
+	assert.ErrorIs(t, log.AppendErr(4), ErrRateLimited)
+	clock.Advance(time.Second)
+	assert.NoError(t, log.AppendErr(5))
+	assert.Equal(t, []int{1, 2, 5}, log.Slice())
+}
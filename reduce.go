@@ -0,0 +1,23 @@
+package memlog
+
+// Reduce folds the entries in log, from oldest to newest,
+// into a single value by calling fn with the accumulated value
+// and each entry, starting with init.  It does not copy the
+// log, but fn is called while the log is locked so it must be
+// fast and must not use the log.
+func Reduce[T, A any](log *MemLog[T], init A, fn func(A, T) A) A {
+	return ReduceN(log, allElements, init, fn)
+}
+
+// ReduceN is like Reduce but only folds the last n entries.
+func ReduceN[T, A any](log *MemLog[T], n int, init A, fn func(A, T) A) A {
+	log.rlock()
+	defer log.runlock()
+
+	acc := init
+	log.forEachN(n, func(item T) {
+		acc = fn(acc, item)
+	})
+
+	return acc
+}
@@ -0,0 +1,66 @@
+package memlog
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reduce_sum(t *testing.T) {
+	log := NewMemLog[float64](10)
+	for _, v := range []float64{1.5, 2.5, 3} {
+		log.Append(v)
+	}
+
+	sum := Reduce(log, 0.0, func(acc, v float64) float64 { return acc + v })
+
+	assert.Equal(t, 7.0, sum)
+}
+
+func Test_reduce_max(t *testing.T) {
+	log := NewMemLog[float64](10)
+	for _, v := range []float64{12, 48, 7} {
+		log.Append(v)
+	}
+
+	max := Reduce(log, math.Inf(-1), math.Max)
+
+	assert.Equal(t, 48.0, max)
+}
+
+func Test_reduce_map(t *testing.T) {
+	// given a log of status codes
+	log := NewMemLog[int](10)
+	for _, code := range []int{200, 500, 200, 404, 200} {
+		log.Append(code)
+	}
+
+	// when folded into a map of counts
+	counts := Reduce(log, map[int]int{}, func(acc map[int]int, code int) map[int]int {
+		acc[code]++
+		return acc
+	})
+
+	// then each code is counted
+	assert.Equal(t, map[int]int{200: 3, 404: 1, 500: 1}, counts)
+}
+
+func Test_reduce_n(t *testing.T) {
+	log := NewMemLog[int](10)
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	sum := func(acc, v int) int { return acc + v }
+	assert.Equal(t, 9, ReduceN(log, 2, 0, sum))
+	assert.Equal(t, 15, ReduceN(log, 10, 0, sum))
+}
+
+func Test_reduce_empty(t *testing.T) {
+	log := NewMemLog[int](10)
+
+	got := Reduce(log, "init", func(acc string, _ int) string { return acc + "!" })
+
+	assert.Equal(t, "init", got)
+}
@@ -0,0 +1,72 @@
+package memlog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrAlreadyRegistered is returned by Register when a log
+// has already been registered with the same name.
+var ErrAlreadyRegistered = errors.New("memlog: name already registered")
+
+// registry holds the logs added by Register, by name.
+var registry = struct {
+	logs   map[string]any
+	locker sync.Mutex
+}{
+	logs: make(map[string]any),
+}
+
+// Register adds log to the package registry under name so
+// that it can be found with Get, for instance by a
+// diagnostics handler.  log is normally a *MemLog of any
+// entry type.  Register returns an error wrapping
+// ErrAlreadyRegistered if name is in use.
+//
+// The registry is safe for concurrent use.
+func Register(name string, log any) error {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	if _, ok := registry.logs[name]; ok {
+		return fmt.Errorf("%w: %q", ErrAlreadyRegistered, name)
+	}
+
+	registry.logs[name] = log
+	return nil
+}
+
+// Unregister removes the log registered under name,
+// if any.
+func Unregister(name string) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+	delete(registry.logs, name)
+}
+
+// Get returns the log registered under name.  The second
+// result is false if no log is registered under name or
+// if the log is not a *MemLog[T].
+func Get[T any](name string) (*MemLog[T], bool) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	log, ok := registry.logs[name].(*MemLog[T])
+	return log, ok
+}
+
+// Names returns the names of the registered logs
+// in sorted order.
+func Names() []string {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	names := make([]string, 0, len(registry.logs))
+	for name := range registry.logs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package memlog
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_registry_register_and_get(t *testing.T) {
+	// given a registered log
+	log := NewMemLog[string](10)
+	assert.NoError(t, Register("test-requests", log))
+	defer Unregister("test-requests")
+
+	// when it is looked up by name
+	got, ok := Get[string]("test-requests")
+
+	// then the same log is returned
+	assert.True(t, ok)
+	assert.Same(t, log, got)
+	assert.Contains(t, Names(), "test-requests")
+}
+
+func Test_registry_rejects_duplicate_names(t *testing.T) {
+	// given a registered log
+	assert.NoError(t, Register("test-duplicate", NewMemLog[int](1)))
+	defer Unregister("test-duplicate")
+
+	// when another log is registered under the same name
+	err := Register("test-duplicate", NewMemLog[int](1))
+
+	// then an error is returned
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+	assert.EqualError(t, err, `memlog: name already registered: "test-duplicate"`)
+}
+
+func Test_registry_get_type_mismatch(t *testing.T) {
+	assert.NoError(t, Register("test-ints", NewMemLog[int](1)))
+	defer Unregister("test-ints")
+
+	log, ok := Get[string]("test-ints")
+	assert.False(t, ok)
+	assert.Nil(t, log)
+
+	_, ok = Get[int]("test-missing")
+	assert.False(t, ok)
+}
+
+func Test_registry_unregister(t *testing.T) {
+	assert.NoError(t, Register("test-unregister", NewMemLog[int](1)))
+	Unregister("test-unregister")
+
+	assert.NotContains(t, Names(), "test-unregister")
+	assert.NoError(t, Register("test-unregister", NewMemLog[int](1)))
+	Unregister("test-unregister")
+}
+
+func Test_registry_concurrent_register_and_lookup(t *testing.T) {
+	// given many goroutines registering and looking up logs
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("test-concurrent-%d", i)
+			assert.NoError(t, Register(name, NewMemLog[int](1)))
+			_, ok := Get[int](name)
+			assert.True(t, ok)
+			Names()
+		}(i)
+	}
+	wg.Wait()
+
+	// then every log was registered once
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("test-concurrent-%d", i)
+		_, ok := Get[int](name)
+		assert.True(t, ok)
+		Unregister(name)
+	}
+}
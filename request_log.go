@@ -0,0 +1,171 @@
+package memlog
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RequestRecord summarizes a request served by a handler
+// wrapped with RequestLogMiddleware.
+type RequestRecord struct {
+	Time       time.Time
+	Method     string
+	Path       string
+	Status     int
+	Duration   time.Duration
+	Bytes      int64
+	RemoteAddr string
+	Hijacked   bool
+}
+
+// RequestLogOptions configures the middleware returned by
+// NewRequestLogMiddleware.
+type RequestLogOptions struct {
+	// RedactQuery causes the query string to be
+	// omitted from the recorded path.
+	RedactQuery bool
+
+	// SkipPaths lists paths, such as "/healthz", whose
+	// requests are not recorded.
+	SkipPaths []string
+}
+
+// RequestLogMiddleware returns an http.Handler that calls next
+// and appends a summary of each request to m.  The recorded
+// path includes the query string.
+func RequestLogMiddleware(m *MemLog[RequestRecord], next http.Handler) http.Handler {
+	return NewRequestLogMiddleware(m, next, RequestLogOptions{})
+}
+
+// NewRequestLogMiddleware is like RequestLogMiddleware but
+// is configured by opts.
+func NewRequestLogMiddleware(m *MemLog[RequestRecord], next http.Handler, opts RequestLogOptions) http.Handler {
+	skip := make(map[string]struct{}, len(opts.SkipPaths))
+	for _, path := range opts.SkipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := skip[r.URL.Path]; ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := m.now()
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec.wrap(), r)
+
+		path := r.URL.Path
+		if !opts.RedactQuery && r.URL.RawQuery != "" {
+			path += "?" + r.URL.RawQuery
+		}
+
+		m.Append(RequestRecord{
+			Time:       start,
+			Method:     r.Method,
+			Path:       path,
+			Status:     rec.statusCode(),
+			Duration:   m.now().Sub(start),
+			Bytes:      rec.bytes,
+			RemoteAddr: r.RemoteAddr,
+			Hijacked:   rec.hijacked,
+		})
+	})
+}
+
+// responseRecorder observes the status and size of
+// a response written to the wrapped ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	bytes    int64
+	hijacked bool
+}
+
+// wrap returns a ResponseWriter writing to the recorder that
+// implements http.Flusher and http.Hijacker when the wrapped
+// ResponseWriter does.
+func (rec *responseRecorder) wrap() http.ResponseWriter {
+	_, flusher := rec.ResponseWriter.(http.Flusher)
+	_, hijacker := rec.ResponseWriter.(http.Hijacker)
+
+	switch {
+	case flusher && hijacker:
+		return &flushHijackRecorder{rec}
+	case flusher:
+		return &flushRecorder{rec}
+	case hijacker:
+		return &hijackRecorder{rec}
+	default:
+		return rec
+	}
+}
+
+// statusCode returns the status written to the client, which
+// is http.StatusOK if the handler did not set one, or 0 if
+// the connection was hijacked without writing a status.
+func (rec *responseRecorder) statusCode() int {
+	if rec.status == 0 && !rec.hijacked {
+		return http.StatusOK
+	}
+	return rec.status
+}
+
+// WriteHeader records the status and writes it to the
+// wrapped ResponseWriter.
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written to the
+// wrapped ResponseWriter.
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap returns the wrapped ResponseWriter for use
+// by http.ResponseController.
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// flush flushes the wrapped ResponseWriter.
+func (rec *responseRecorder) flush() {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack hijacks the connection of the wrapped ResponseWriter.
+func (rec *responseRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := rec.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		rec.hijacked = true
+	}
+	return conn, rw, err
+}
+
+type flushRecorder struct{ *responseRecorder }
+
+func (f *flushRecorder) Flush() { f.flush() }
+
+type hijackRecorder struct{ *responseRecorder }
+
+func (h *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return h.hijack() }
+
+type flushHijackRecorder struct{ *responseRecorder }
+
+func (f *flushHijackRecorder) Flush() { f.flush() }
+
+func (f *flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return f.hijack() }
@@ -0,0 +1,131 @@
+package memlog
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_request_log_records_requests(t *testing.T) {
+	// given a middleware using a fake clock
+	clock := NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	requests := NewMemLog(10, WithClock[RequestRecord](clock))
+	handler := RequestLogMiddleware(requests, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(25 * time.Millisecond)
+		http.Error(w, "missing", http.StatusNotFound)
+	}))
+
+	// when a request is served
+	req := httptest.NewRequest(http.MethodPost, "/items/7?verbose=1", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// then a summary is recorded
+	assert.Equal(t, []RequestRecord{{
+		Time:       clock.Now().Add(-25 * time.Millisecond),
+		Method:     http.MethodPost,
+		Path:       "/items/7?verbose=1",
+		Status:     http.StatusNotFound,
+		Duration:   25 * time.Millisecond,
+		Bytes:      8,
+		RemoteAddr: "10.0.0.1:5000",
+	}}, requests.Slice())
+}
+
+func Test_request_log_implicit_status(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		bytes   int64
+	}{
+		{"write without header", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "hello") }, 5},
+		{"no write", func(w http.ResponseWriter, r *http.Request) {}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := NewMemLog[RequestRecord](10)
+			handler := RequestLogMiddleware(requests, tt.handler)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			record := requests.Slice()[0]
+			assert.Equal(t, http.StatusOK, record.Status)
+			assert.Equal(t, tt.bytes, record.Bytes)
+		})
+	}
+}
+
+func Test_request_log_options(t *testing.T) {
+	// given a middleware that skips health checks and redacts queries
+	requests := NewMemLog[RequestRecord](10)
+	handler := NewRequestLogMiddleware(requests, http.NotFoundHandler(), RequestLogOptions{
+		RedactQuery: true,
+		SkipPaths:   []string{"/healthz"},
+	})
+
+	// when requests are served
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/login?token=secret", nil))
+
+	// then only other paths are recorded, without their query
+	records := requests.Slice()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "/login", records[0].Path)
+}
+
+func Test_request_log_preserves_flusher(t *testing.T) {
+	requests := NewMemLog[RequestRecord](10)
+	handler := RequestLogMiddleware(requests, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hijacker := w.(http.Hijacker)
+		assert.False(t, hijacker)
+		w.(http.Flusher).Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, http.StatusOK, requests.Slice()[0].Status)
+}
+
+func Test_request_log_hijacked_connection(t *testing.T) {
+	// given a server whose handler hijacks the connection
+	requests := NewMemLog[RequestRecord](10)
+	handler := RequestLogMiddleware(requests, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flusher := w.(http.Flusher)
+		assert.True(t, flusher)
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n\r\n")
+		rw.Flush()
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// when a client connects
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	assert.NoError(t, err)
+	defer conn.Close()
+	fmt.Fprint(conn, "GET /ws HTTP/1.1\r\nHost: test\r\n\r\n")
+	status, err := bufio.NewReader(conn).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1 101 Switching Protocols\r\n", status)
+
+	// then the request is recorded as hijacked
+	assert.Eventually(t, func() bool { return requests.Len() == 1 }, time.Second, 10*time.Millisecond)
+	record := requests.Slice()[0]
+	assert.True(t, record.Hijacked)
+	assert.Equal(t, 0, record.Status)
+	assert.Equal(t, "/ws", record.Path)
+}
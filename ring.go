@@ -0,0 +1,132 @@
+package memlog
+
+// minRingCap is the initial capacity of a ring
+// once the first entry is added.
+const minRingCap = 16
+
+// ring is a circular buffer of entries that grows as
+// needed, up to a limit, and supports random access.
+type ring[T any] struct {
+	buf   []sequenced[T]
+	head  int
+	count int
+}
+
+// len returns the number of entries in the ring.
+func (r *ring[T]) len() int {
+	return r.count
+}
+
+// at returns the entry at position i, where
+// 0 is the oldest entry.
+func (r *ring[T]) at(i int) *sequenced[T] {
+	return &r.buf[(r.head+i)%len(r.buf)]
+}
+
+// back returns the newest entry.  The ring
+// must not be empty.
+func (r *ring[T]) back() *sequenced[T] {
+	return r.at(r.count - 1)
+}
+
+// pushBack adds entry after the newest entry, growing the
+// buffer if it is full.  The buffer does not grow beyond
+// limit entries, so the ring must hold fewer than limit
+// entries.
+func (r *ring[T]) pushBack(entry sequenced[T], limit int) {
+	if r.count == len(r.buf) {
+		r.grow(limit)
+	}
+
+	*r.at(r.count) = entry
+	r.count++
+}
+
+// insert adds entry at position i, growing the buffer if it
+// is full.  Whichever of the entries before or after i are
+// fewer are moved to make room.  The ring must hold fewer
+// than limit entries.
+func (r *ring[T]) insert(i int, entry sequenced[T], limit int) {
+	if r.count == len(r.buf) {
+		r.grow(limit)
+	}
+
+	if i < r.count/2 {
+		r.head = (r.head + len(r.buf) - 1) % len(r.buf)
+		for j := 0; j < i; j++ {
+			*r.at(j) = *r.at(j + 1)
+		}
+	} else {
+		for j := r.count; j > i; j-- {
+			*r.at(j) = *r.at(j - 1)
+		}
+	}
+
+	*r.at(i) = entry
+	r.count++
+}
+
+// grow increases the capacity of the buffer, up to limit,
+// moving the entries to the start of the new buffer.
+func (r *ring[T]) grow(limit int) {
+	capacity := 2 * len(r.buf)
+	if capacity < minRingCap {
+		capacity = minRingCap
+	}
+	if capacity > limit {
+		capacity = limit
+	}
+
+	buf := make([]sequenced[T], capacity)
+	for i := 0; i < r.count; i++ {
+		buf[i] = *r.at(i)
+	}
+
+	r.buf = buf
+	r.head = 0
+}
+
+// popFront removes and returns the oldest entry.
+// The ring must not be empty.
+func (r *ring[T]) popFront() sequenced[T] {
+	front := r.at(0)
+	entry := *front
+	*front = sequenced[T]{}
+
+	r.head = (r.head + 1) % len(r.buf)
+	r.count--
+	return entry
+}
+
+// remove removes the entry at position i.  Whichever of
+// the entries before or after i are fewer are moved to
+// close the gap.
+func (r *ring[T]) remove(i int) {
+	if i < r.count/2 {
+		for j := i; j > 0; j-- {
+			*r.at(j) = *r.at(j - 1)
+		}
+		r.popFront()
+		return
+	}
+
+	for j := i; j < r.count-1; j++ {
+		*r.at(j) = *r.at(j + 1)
+	}
+	r.truncate(r.count - 1)
+}
+
+// truncate removes all but the oldest n entries.
+func (r *ring[T]) truncate(n int) {
+	for i := n; i < r.count; i++ {
+		*r.at(i) = sequenced[T]{}
+	}
+	r.count = n
+}
+
+// reset removes all entries, keeping the buffer.
+func (r *ring[T]) reset() {
+	clear(r.buf)
+	r.head = 0
+	r.count = 0
+}
@@ -0,0 +1,24 @@
+package memlog
+
+// ringLog is implemented by the logs that keep
+// the newest entries up to a fixed capacity.
+type ringLog[T any] interface {
+	Append(item T)
+	Cap() int
+	Slice() []T
+}
+
+var (
+	_ ringLog[int] = (*MemLog[int])(nil)
+	_ ringLog[int] = (*AtomicMemLog[int])(nil)
+	_ ringLog[int] = (*ShardedMemLog[int])(nil)
+)
+
+// NewRingLog returns a new MemLog that will not grow beyond
+// size entries.  It is equivalent to NewMemLog and exists to
+// make the storage explicit: entries are held in a ring
+// buffer, so Append takes constant time and, once the log
+// is full, does not allocate.
+func NewRingLog[T any](size int, opts ...Option[T]) *MemLog[T] {
+	return NewMemLog(size, opts...)
+}
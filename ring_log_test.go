@@ -0,0 +1,40 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ring_log_append_does_not_allocate_when_full(t *testing.T) {
+	// given a full ring log
+	log := NewRingLog[int](100)
+	for i := 0; i < 100; i++ {
+		log.Append(i)
+	}
+
+	// when more entries are appended
+	i := 100
+	allocs := testing.AllocsPerRun(1000, func() {
+		log.Append(i)
+		i++
+	})
+
+	// then nothing is allocated and the newest entries are kept
+	assert.Zero(t, allocs)
+	assert.Equal(t, 100, log.Len())
+	assert.Equal(t, []int{i - 2, i - 1}, log.SliceN(2))
+}
+
+func Benchmark_ring_log_append(b *testing.B) {
+	log := NewRingLog[int](1000)
+	for i := 0; i < 1000; i++ {
+		log.Append(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Append(i)
+	}
+}
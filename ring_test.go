@@ -0,0 +1,103 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ring_wraps_around(t *testing.T) {
+	// given a full ring
+	var r ring[int]
+	for i := 0; i < 4; i++ {
+		r.pushBack(sequenced[int]{seq: uint64(i), value: i}, 4)
+	}
+
+	// when entries are removed from the front and added to the back
+	assert.Equal(t, 0, r.popFront().value)
+	assert.Equal(t, 1, r.popFront().value)
+	r.pushBack(sequenced[int]{value: 4}, 4)
+	r.pushBack(sequenced[int]{value: 5}, 4)
+
+	// then the entries remain in order across the wrap
+	assert.Equal(t, 4, r.len())
+	assert.Len(t, r.buf, 4)
+	for i, want := range []int{2, 3, 4, 5} {
+		assert.Equal(t, want, r.at(i).value)
+	}
+	assert.Equal(t, 5, r.back().value)
+}
+
+func Test_ring_grows_to_limit(t *testing.T) {
+	// given an empty ring with a limit above the initial capacity
+	var r ring[int]
+
+	// when more entries than the initial capacity are added
+	for i := 0; i < minRingCap+1; i++ {
+		r.pushBack(sequenced[int]{value: i}, minRingCap+4)
+	}
+
+	// then the buffer grows no larger than the limit
+	assert.Equal(t, minRingCap+1, r.len())
+	assert.Len(t, r.buf, minRingCap+4)
+	assert.Equal(t, minRingCap, r.back().value)
+}
+
+func Test_ring_truncate_and_reset(t *testing.T) {
+	var r ring[int]
+	for i := 0; i < 5; i++ {
+		r.pushBack(sequenced[int]{value: i}, 10)
+	}
+
+	r.truncate(2)
+	assert.Equal(t, 2, r.len())
+	assert.Equal(t, 1, r.back().value)
+
+	r.reset()
+	assert.Equal(t, 0, r.len())
+	assert.NotEmpty(t, r.buf)
+}
+
+func Test_ring_insert(t *testing.T) {
+	// given a ring that has wrapped
+	var r ring[int]
+	for i := 0; i < 6; i++ {
+		r.pushBack(sequenced[int]{value: i * 10}, 8)
+	}
+	r.popFront()
+	r.popFront()
+
+	// when entries are inserted near the front, middle and back
+	r.insert(0, sequenced[int]{value: 15}, 8)
+	r.insert(2, sequenced[int]{value: 25}, 8)
+	r.insert(5, sequenced[int]{value: 45}, 8)
+	r.insert(7, sequenced[int]{value: 55}, 8)
+
+	// then every entry is in position
+	var values []int
+	for i := 0; i < r.len(); i++ {
+		values = append(values, r.at(i).value)
+	}
+	assert.Equal(t, []int{15, 20, 25, 30, 40, 45, 50, 55}, values)
+}
+
+func Test_ring_remove(t *testing.T) {
+	// given a ring that has wrapped
+	var r ring[int]
+	for i := 0; i < 8; i++ {
+		r.pushBack(sequenced[int]{value: i}, 8)
+	}
+	r.popFront()
+	r.pushBack(sequenced[int]{value: 8}, 8)
+
+	// when entries are removed near the front and back
+	r.remove(1)
+	r.remove(5)
+
+	// then the remaining entries keep their order
+	var values []int
+	for i := 0; i < r.len(); i++ {
+		values = append(values, r.at(i).value)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 6, 8}, values)
+}
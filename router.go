@@ -0,0 +1,75 @@
+package memlog
+
+import "sync"
+
+// RouteMode determines which logs a Router
+// appends an entry to.
+type RouteMode int
+
+const (
+	// RouteFirst appends an entry to the log of the
+	// first route whose predicate matches.
+	RouteFirst RouteMode = iota
+
+	// RouteAll appends an entry to the logs of
+	// every route whose predicate matches.
+	RouteAll
+)
+
+// route pairs a predicate with the log that
+// receives matching entries.
+type route[T any] struct {
+	predicate func(T) bool
+	log       *MemLog[T]
+}
+
+// Router dispatches entries to different logs based on
+// predicates, for instance to route log entries by level
+// or service name.  Entries that match no route are
+// discarded.
+//
+// Router is thread-safe
+type Router[T any] struct {
+	routes []route[T]
+	mode   RouteMode
+	locker sync.Mutex
+}
+
+// NewRouter returns a Router with no routes that
+// appends each entry to the first matching log.
+func NewRouter[T any]() *Router[T] {
+	return &Router[T]{}
+}
+
+// SetMode sets which matching logs entries are appended to.
+func (r *Router[T]) SetMode(mode RouteMode) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	r.mode = mode
+}
+
+// Add registers a route that appends entries for which
+// predicate returns true to log.  Routes are evaluated
+// in the order they are added.
+func (r *Router[T]) Add(predicate func(T) bool, log *MemLog[T]) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	r.routes = append(r.routes, route[T]{predicate: predicate, log: log})
+}
+
+// Append evaluates each route's predicate in registration
+// order and appends item to the matching logs.
+func (r *Router[T]) Append(item T) {
+	r.locker.Lock()
+	routes, mode := r.routes, r.mode
+	r.locker.Unlock()
+
+	for _, rt := range routes {
+		if rt.predicate(item) {
+			rt.log.Append(item)
+			if mode == RouteFirst {
+				return
+			}
+		}
+	}
+}
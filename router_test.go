@@ -0,0 +1,73 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_router_append(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      RouteMode
+		item      LevelEntry[string]
+		errors    []string
+		important []string
+		debug     []string
+	}{
+		{
+			name:  "single match",
+			mode:  RouteFirst,
+			item:  LevelEntry[string]{Level: LevelDebug, Value: "d"},
+			debug: []string{"d"},
+		},
+		{
+			name:   "first of several matches",
+			mode:   RouteFirst,
+			item:   LevelEntry[string]{Level: LevelError, Value: "e"},
+			errors: []string{"e"},
+		},
+		{
+			name:      "all matches",
+			mode:      RouteAll,
+			item:      LevelEntry[string]{Level: LevelError, Value: "e"},
+			errors:    []string{"e"},
+			important: []string{"e"},
+		},
+		{
+			name: "no match",
+			mode: RouteAll,
+			item: LevelEntry[string]{Level: LevelInfo, Value: "i"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given a router with overlapping routes
+			errors := NewMemLog[LevelEntry[string]](10)
+			important := NewMemLog[LevelEntry[string]](10)
+			debug := NewMemLog[LevelEntry[string]](10)
+
+			r := NewRouter[LevelEntry[string]]()
+			r.SetMode(tt.mode)
+			r.Add(func(e LevelEntry[string]) bool { return e.Level == LevelError }, errors)
+			r.Add(func(e LevelEntry[string]) bool { return e.Level >= LevelWarn }, important)
+			r.Add(func(e LevelEntry[string]) bool { return e.Level == LevelDebug }, debug)
+
+			// when an entry is appended
+			r.Append(tt.item)
+
+			// then it is routed to the expected logs
+			values := func(log *MemLog[LevelEntry[string]]) []string {
+				var v []string
+				for _, e := range log.Slice() {
+					v = append(v, e.Value)
+				}
+				return v
+			}
+			assert.Equal(t, tt.errors, values(errors))
+			assert.Equal(t, tt.important, values(important))
+			assert.Equal(t, tt.debug, values(debug))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package memlog
+
+import "time"
+
+// Run is a value that was appended one or more times
+// consecutively, along with when it was first and
+// last appended.
+type Run[T any] struct {
+	Value     T
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// RunLog is a MemLog that collapses consecutive equal entries
+// into a single Run, in the style of syslog's "last message
+// repeated N times".  Appending a value equal to the newest
+// run increments its count; any other value starts a new run.
+//
+// RunLog is thread-safe
+type RunLog[T any] struct {
+	Buffer *MemLog[Run[T]]
+}
+
+// NewRunLog returns a new RunLog that will not grow
+// beyond size runs.
+func NewRunLog[T comparable](size int, opts ...Option[Run[T]]) *RunLog[T] {
+	return NewRunLogFunc(size, func(a, b T) bool {
+		return a == b
+	}, opts...)
+}
+
+// NewRunLogFunc is like NewRunLog but uses equal to decide
+// whether an entry continues the newest run.
+func NewRunLogFunc[T any](size int, equal func(a, b T) bool, opts ...Option[Run[T]]) *RunLog[T] {
+	merge := func(last, item Run[T]) (Run[T], bool) {
+		if !equal(last.Value, item.Value) {
+			return last, false
+		}
+		last.Count += item.Count
+		last.LastSeen = item.LastSeen
+		return last, true
+	}
+
+	return &RunLog[T]{
+		Buffer: NewMemLog(size, append(opts, withMerge(merge))...),
+	}
+}
+
+// Append adds v to the log, extending the newest run
+// if it has an equal value.
+func (r *RunLog[T]) Append(v T) {
+	now := r.Buffer.now()
+	r.Buffer.Append(Run[T]{Value: v, Count: 1, FirstSeen: now, LastSeen: now})
+}
+
+// Runs returns the runs in the log.  The slice is
+// ordered from oldest item to the newest.
+func (r *RunLog[T]) Runs() []Run[T] {
+	return r.Buffer.Slice()
+}
+
+// Slice returns the value of each run in the log.
+// The slice is ordered from oldest item to the newest.
+func (r *RunLog[T]) Slice() []T {
+	runs := r.Runs()
+
+	slice := make([]T, len(runs))
+	for i, run := range runs {
+		slice[i] = run.Value
+	}
+
+	return slice
+}
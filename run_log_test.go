@@ -0,0 +1,94 @@
+package memlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_run_log_alternating_values(t *testing.T) {
+	// given a run log
+	log := NewRunLog[string](10)
+
+	// when values alternate
+	for _, v := range []string{"a", "b", "a", "a", "b"} {
+		log.Append(v)
+	}
+
+	// then a different value breaks each run
+	assert.Equal(t, []string{"a", "b", "a", "b"}, log.Slice())
+
+	var counts []int
+	for _, run := range log.Runs() {
+		counts = append(counts, run.Count)
+	}
+	assert.Equal(t, []int{1, 1, 2, 1}, counts)
+}
+
+func Test_run_log_first_and_last_seen(t *testing.T) {
+	// given a run log with a controlled clock
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewRunLog(10, WithClock[Run[string]](clock))
+
+	// when a value is repeated over time
+	log.Append("disk full")
+	clock.Advance(time.Second)
+	log.Append("disk full")
+	clock.Advance(time.Second)
+	log.Append("disk full")
+
+	// then the run spans the first and last append
+	assert.Equal(t, []Run[string]{{
+		Value:     "disk full",
+		Count:     3,
+		FirstSeen: start,
+		LastSeen:  start.Add(2 * time.Second),
+	}}, log.Runs())
+}
+
+func Test_run_log_across_eviction(t *testing.T) {
+	// given a run log that holds two runs
+	log := NewRunLog[string](2)
+
+	// when a run continues after older runs are evicted
+	log.Append("a")
+	log.Append("b")
+	log.Append("c")
+	log.Append("c")
+	log.Append("c")
+
+	// then the run is extended in place
+	runs := log.Runs()
+	assert.Equal(t, []string{"b", "c"}, log.Slice())
+	assert.Equal(t, 3, runs[1].Count)
+}
+
+func Test_run_log_count_accuracy(t *testing.T) {
+	log := NewRunLog[int](10)
+
+	for i := 0; i < 50000; i++ {
+		log.Append(i / 10000)
+	}
+
+	runs := log.Runs()
+	assert.Len(t, runs, 5)
+	for i, run := range runs {
+		assert.Equal(t, i, run.Value)
+		assert.Equal(t, 10000, run.Count)
+	}
+}
+
+func Test_run_log_func(t *testing.T) {
+	log := NewRunLogFunc(10, func(a, b []string) bool {
+		return len(a) == len(b)
+	})
+
+	log.Append([]string{"x"})
+	log.Append([]string{"y"})
+	log.Append([]string{"x", "y"})
+
+	assert.Len(t, log.Runs(), 2)
+	assert.Equal(t, 2, log.Runs()[0].Count)
+}
@@ -0,0 +1,25 @@
+package memlog
+
+import "math/rand"
+
+// WithSampling causes each appended entry to be kept with
+// probability p and dropped otherwise, so that a statistical
+// sample of a high volume of entries is retained rather than
+// only the newest.  Dropped entries are counted in Stats.
+// Entries that are kept still evict the oldest entry when the
+// log is full.  Use AppendAlways to add an entry regardless.
+//
+// Random numbers are taken from rng, or from the default
+// source if rng is nil.
+func WithSampling[T any](p float64, rng *rand.Rand) Option[T] {
+	random := rand.Float64
+	if rng != nil {
+		random = rng.Float64
+	}
+
+	return func(m *MemLog[T]) {
+		m.sample = func() bool {
+			return random() < p
+		}
+	}
+}
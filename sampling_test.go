@@ -0,0 +1,49 @@
+package memlog
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memlog_sampling_keep_ratio(t *testing.T) {
+	// given a log keeping a quarter of its entries
+	log := NewMemLog[int](100000, WithSampling[int](0.25, rand.New(rand.NewSource(1))))
+
+	// when many entries are appended
+	for i := 0; i < 100000; i++ {
+		log.Append(i)
+	}
+
+	// then about a quarter are kept and the rest counted
+	assert.InDelta(t, 25000, log.Len(), 500)
+	stats := log.Stats()
+	assert.Equal(t, uint64(100000), stats.TotalAppends+stats.TotalSampledOut)
+}
+
+func Test_memlog_sampling_respects_size(t *testing.T) {
+	log := NewMemLog[int](10, WithSampling[int](0.5, rand.New(rand.NewSource(1))))
+
+	for i := 0; i < 1000; i++ {
+		log.Append(i)
+	}
+
+	assert.Equal(t, 10, log.Len())
+	assert.NotZero(t, log.Stats().TotalEvictions)
+}
+
+func Test_memlog_sampling_append_always(t *testing.T) {
+	// given a log that samples no entries
+	log := NewMemLog[string](10, WithSampling[string](0, nil))
+
+	// when entries are appended normally and with AppendAlways
+	log.Append("debug")
+	err := log.AppendErr("info")
+	log.AppendAlways("error")
+
+	// then only the must-keep entry is stored
+	assert.ErrorIs(t, err, ErrSampledOut)
+	assert.Equal(t, []string{"error"}, log.Slice())
+	assert.Equal(t, uint64(2), log.Stats().TotalSampledOut)
+}
@@ -0,0 +1,34 @@
+package memlog
+
+// Intersect returns the entries of a that are also present
+// in b, ordered from oldest to newest as they are in a.  An
+// entry that appears more than once in a is included each
+// time it appears.
+func Intersect[T comparable](a, b *MemLog[T]) []T {
+	return filterMembers(a, b, true)
+}
+
+// Subtract returns the entries of a that are not present
+// in b, ordered from oldest to newest as they are in a.  An
+// entry that appears more than once in a is included each
+// time it appears.
+func Subtract[T comparable](a, b *MemLog[T]) []T {
+	return filterMembers(a, b, false)
+}
+
+// filterMembers returns the entries of a whose membership
+// in b is member.
+func filterMembers[T comparable](a, b *MemLog[T], member bool) []T {
+	set := make(map[T]struct{})
+	for _, item := range b.Slice() {
+		set[item] = struct{}{}
+	}
+
+	var slice []T
+	for _, item := range a.Slice() {
+		if _, ok := set[item]; ok == member {
+			slice = append(slice, item)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,62 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_intersect_disjoint_logs(t *testing.T) {
+	// given two logs with no values in common
+	a := NewMemLog(10, WithInitialEntries([]int{1, 2, 3}))
+	b := NewMemLog(10, WithInitialEntries([]int{4, 5}))
+
+	// when they are intersected and subtracted
+	intersect := Intersect(a, b)
+	subtract := Subtract(a, b)
+
+	// then nothing is shared and every value of a remains
+	assert.Nil(t, intersect)
+	assert.Equal(t, []int{1, 2, 3}, subtract)
+}
+
+func Test_intersect_overlapping_logs(t *testing.T) {
+	// given two logs holding the same values in different orders
+	a := NewMemLog(10, WithInitialEntries([]int{3, 1, 2}))
+	b := NewMemLog(10, WithInitialEntries([]int{1, 2, 3}))
+
+	// when they are intersected and subtracted
+	intersect := Intersect(a, b)
+	subtract := Subtract(a, b)
+
+	// then every value is shared, in the order of a
+	assert.Equal(t, []int{3, 1, 2}, intersect)
+	assert.Nil(t, subtract)
+}
+
+func Test_intersect_keeps_duplicates(t *testing.T) {
+	// given logs that partially overlap and contain duplicates
+	a := NewMemLog(10, WithInitialEntries([]int{1, 2, 2, 3, 1}))
+	b := NewMemLog(10, WithInitialEntries([]int{2, 2, 4}))
+
+	// when they are intersected and subtracted
+	intersect := Intersect(a, b)
+	subtract := Subtract(a, b)
+
+	// then each entry of a is kept or removed individually
+	assert.Equal(t, []int{2, 2}, intersect)
+	assert.Equal(t, []int{1, 3, 1}, subtract)
+}
+
+func Test_intersect_empty_logs(t *testing.T) {
+	// given an empty log and a log with entries
+	empty := NewMemLog[int](10)
+	full := NewMemLog(10, WithInitialEntries([]int{1, 1}))
+
+	// when an empty log is intersected or subtracted
+	// then only the entries of a non-empty a remain
+	assert.Nil(t, Intersect(empty, full))
+	assert.Nil(t, Subtract(empty, full))
+	assert.Nil(t, Intersect(full, empty))
+	assert.Equal(t, []int{1, 1}, Subtract(full, empty))
+}
@@ -0,0 +1,121 @@
+package memlog
+
+import (
+	"runtime"
+	"sort"
+	"sync/atomic"
+)
+
+// ShardedMemLog is a bounded log that spreads appends across
+// several internal MemLogs to reduce lock contention when
+// many goroutines append concurrently.  Each entry is stamped
+// with a sequence number so the global order can be restored
+// when the log is read.
+//
+// Entries are distributed round-robin and each shard holds an
+// equal share of the total size, rounded up, so that the most
+// recent size entries are always retained.  Any extra entries
+// held by the shards are not returned.  Reads lock each shard in
+// turn, so a Slice taken during concurrent appends may not
+// include every entry appended before it returns.
+//
+// ShardedMemLog is thread-safe
+type ShardedMemLog[T any] struct {
+	shards []*MemLog[sequenced[T]]
+	seq    atomic.Uint64
+	size   int
+}
+
+// NewShardedMemLog returns a new ShardedMemLog that will not
+// grow beyond size entries in total, split across the given
+// number of shards.  If shards is less than 1 GOMAXPROCS
+// shards are used.
+func NewShardedMemLog[T any](size int, shards int) *ShardedMemLog[T] {
+	if shards < 1 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	if shards > size {
+		shards = size
+	}
+	if shards < 1 {
+		shards = 1
+	}
+
+	s := &ShardedMemLog[T]{
+		shards: make([]*MemLog[sequenced[T]], shards),
+		size:   size,
+	}
+
+	shardSize := (size + shards - 1) / shards
+	for i := range s.shards {
+		s.shards[i] = NewMemLog[sequenced[T]](shardSize)
+	}
+
+	return s
+}
+
+// Append will add item to the log, removing the oldest
+// entry in its shard if the shard is full.
+func (s *ShardedMemLog[T]) Append(item T) {
+	seq := s.seq.Add(1) - 1
+	shard := s.shards[seq%uint64(len(s.shards))]
+	shard.Append(sequenced[T]{seq: seq, value: item})
+}
+
+// Len returns the number of elements in the log.
+func (s *ShardedMemLog[T]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	if n > s.size {
+		n = s.size
+	}
+	return n
+}
+
+// Cap returns the maximum number of
+// entries the log will hold.
+func (s *ShardedMemLog[T]) Cap() int {
+	return s.size
+}
+
+// Clear will clear the current contents of the log.
+func (s *ShardedMemLog[T]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// Slice returns the contents of the log as a slice.
+// The slice is ordered from oldest item to the newest
+func (s *ShardedMemLog[T]) Slice() []T {
+	return s.SliceN(allElements)
+}
+
+// SliceN returns the last 'N' items from the log.
+// The slice is ordered from oldest item to the newest
+func (s *ShardedMemLog[T]) SliceN(n int) []T {
+	var merged []sequenced[T]
+	for _, shard := range s.shards {
+		merged = append(merged, shard.Slice()...)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].seq < merged[j].seq
+	})
+
+	if n <= allElements || n > s.size {
+		n = s.size
+	}
+	if n < len(merged) {
+		merged = merged[len(merged)-n:]
+	}
+
+	slice := make([]T, len(merged))
+	for i, entry := range merged {
+		slice[i] = entry.value
+	}
+
+	return slice
+}
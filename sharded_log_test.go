@@ -0,0 +1,119 @@
+package memlog
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sharded_memlog_ordering(t *testing.T) {
+	// given a sharded log
+	log := NewShardedMemLog[int](10, 4)
+
+	// when more entries are appended than it holds
+	for i := 0; i < 25; i++ {
+		log.Append(i)
+	}
+
+	// then the most recent entries are returned in order
+	assert.Equal(t, 10, log.Len())
+	assert.Equal(t, 10, log.Cap())
+	assert.Equal(t, []int{15, 16, 17, 18, 19, 20, 21, 22, 23, 24}, log.Slice())
+	assert.Equal(t, []int{22, 23, 24}, log.SliceN(3))
+
+	log.Clear()
+	assert.Empty(t, log.Slice())
+}
+
+func Test_sharded_memlog_default_shards(t *testing.T) {
+	log := NewShardedMemLog[int](1000, 0)
+	assert.Len(t, log.shards, runtime.GOMAXPROCS(0))
+
+	small := NewShardedMemLog[int](2, 8)
+	assert.Len(t, small.shards, 2)
+}
+
+func Test_sharded_memlog_concurrent_appends(t *testing.T) {
+	// given a sharded log
+	const goroutines, perGoroutine, size = 16, 1000, 100
+	log := NewShardedMemLog[[2]int](size, 4)
+
+	// when many goroutines append concurrently
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				log.Append([2]int{g, i})
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// then the size is bounded and each goroutine's entries are in order
+	slice := log.Slice()
+	assert.Len(t, slice, size)
+
+	last := make(map[int]int)
+	for _, entry := range slice {
+		if prev, ok := last[entry[0]]; ok {
+			assert.Less(t, prev, entry[1])
+		}
+		last[entry[0]] = entry[1]
+	}
+}
+
+func Benchmark_memlog_append_parallel(b *testing.B) {
+	log := NewMemLog[int](1000)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			log.Append(1)
+		}
+	})
+}
+
+func Benchmark_sharded_memlog_append_parallel(b *testing.B) {
+	log := NewShardedMemLog[int](1000, 0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			log.Append(1)
+		}
+	})
+}
+
+func Benchmark_sharded_memlog_append_contended(b *testing.B) {
+	const goroutines = 16
+
+	benchmarks := []struct {
+		name   string
+		append func(int)
+	}{
+		{"sharded", NewShardedMemLog[int](1000, goroutines).Append},
+		{"single", NewMemLog[int](1000).Append},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var wg sync.WaitGroup
+			for g := 0; g < goroutines; g++ {
+				// split b.N between the goroutines
+				n := b.N / goroutines
+				if g < b.N%goroutines {
+					n++
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < n; i++ {
+						bm.append(i)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package memlog
+
+import "sync"
+
+// borrowed is a pooled slice along with the
+// function that returns it to the pool.
+type borrowed[T any] struct {
+	buf     []T
+	release func()
+	out     bool
+}
+
+// WithSlicePool causes SliceBorrow to reuse slices from a
+// pool rather than allocating a new slice for each call.
+// This is useful when large logs are read frequently.
+func WithSlicePool[T any]() Option[T] {
+	return func(m *MemLog[T]) {
+		m.pool = &sync.Pool{}
+	}
+}
+
+// SliceBorrow returns the contents of the log as a slice,
+// ordered from oldest item to the newest, along with a
+// function that releases the slice.  When the log was created
+// with WithSlicePool the slice is taken from a pool and
+// release returns it to the pool; otherwise a new slice is
+// allocated and release does nothing.
+//
+// The slice must not be used or retained after release is
+// called, since it may be overwritten by a later call to
+// SliceBorrow.  release must be called at most once.
+func (m *MemLog[T]) SliceBorrow() ([]T, func()) {
+	if m.pool == nil {
+		return m.Slice(), func() {}
+	}
+
+	b, _ := m.pool.Get().(*borrowed[T])
+	if b == nil {
+		b = &borrowed[T]{}
+		b.release = func() {
+			if !b.out {
+				return
+			}
+			b.out = false
+			clear(b.buf)
+			m.pool.Put(b)
+		}
+	}
+	b.out = true
+
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	n := m.entries.len()
+	if cap(b.buf) < n {
+		b.buf = make([]T, n)
+	}
+	b.buf = b.buf[:n]
+
+	i := 0
+	m.forEachN(allElements, func(item T) {
+		b.buf[i] = item
+		i++
+	})
+
+	return b.buf, b.release
+}
@@ -0,0 +1,77 @@
+package memlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memlog_slice_borrow_without_pool(t *testing.T) {
+	log := NewMemLog[int](5)
+	log.Append(1)
+	log.Append(2)
+
+	slice, release := log.SliceBorrow()
+	defer release()
+
+	assert.Equal(t, []int{1, 2}, slice)
+}
+
+func Test_memlog_slice_borrow_reuses_buffers(t *testing.T) {
+	// given a pooled log
+	log := NewMemLog[int](5, WithSlicePool[int]())
+	for i := 1; i <= 5; i++ {
+		log.Append(i)
+	}
+
+	// when snapshots of different sizes are borrowed and released
+	slice, release := log.SliceBorrow()
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slice)
+	release()
+	release()
+
+	log.Clear()
+	log.Append(6)
+	log.Append(7)
+	slice, release = log.SliceBorrow()
+
+	// then each snapshot holds only the current contents
+	assert.Equal(t, []int{6, 7}, slice)
+
+	// and a second borrow while the first is held is independent
+	other, releaseOther := log.SliceBorrow()
+	log.Append(8)
+	assert.Equal(t, []int{6, 7}, slice)
+	assert.Equal(t, []int{6, 7}, other)
+	release()
+	releaseOther()
+
+	slice, release = log.SliceBorrow()
+	assert.Equal(t, []int{6, 7, 8}, slice)
+	release()
+}
+
+func Benchmark_memlog_slice(b *testing.B) {
+	log := NewMemLog[int](5000)
+	for i := 0; i < 5000; i++ {
+		log.Append(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = log.Slice()
+	}
+}
+
+func Benchmark_memlog_slice_borrow(b *testing.B) {
+	log := NewMemLog[int](5000, WithSlicePool[int]())
+	for i := 0; i < 5000; i++ {
+		log.Append(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, release := log.SliceBorrow()
+		release()
+	}
+}
@@ -0,0 +1,53 @@
+package memlog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// MemLogHandler is a slog.Handler that formats records
+// as text and stores each one as an entry in a MemLog.
+type MemLogHandler struct {
+	Buffer  *MemLog[string]
+	handler slog.Handler
+}
+
+// NewMemLogHandler returns a MemLogHandler that will keep
+// at most size records.  Records are formatted in the same
+// way as slog.TextHandler using opts, which may be nil.
+func NewMemLogHandler(size int, opts *slog.HandlerOptions) *MemLogHandler {
+	sl := NewStringLog(size)
+	return &MemLogHandler{
+		Buffer:  sl.Buffer,
+		handler: slog.NewTextHandler(sl, opts),
+	}
+}
+
+// Enabled reports whether the handler handles records
+// at the given level.
+func (h *MemLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle formats r and appends it to the buffer.
+func (h *MemLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new MemLogHandler, sharing the same
+// buffer, whose records include attrs.
+func (h *MemLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &MemLogHandler{
+		Buffer:  h.Buffer,
+		handler: h.handler.WithAttrs(attrs),
+	}
+}
+
+// WithGroup returns a new MemLogHandler, sharing the same
+// buffer, that qualifies subsequent attributes with name.
+func (h *MemLogHandler) WithGroup(name string) slog.Handler {
+	return &MemLogHandler{
+		Buffer:  h.Buffer,
+		handler: h.handler.WithGroup(name),
+	}
+}
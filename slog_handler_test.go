@@ -0,0 +1,70 @@
+package memlog
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// noTime removes the time attribute so output is predictable.
+func noTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func Test_memlog_handler_stores_records(t *testing.T) {
+	// given a logger backed by a memlog handler
+	handler := NewMemLogHandler(10, &slog.HandlerOptions{ReplaceAttr: noTime})
+	logger := slog.New(handler)
+
+	// when messages are logged
+	logger.Info("first", "count", 1)
+	logger.Warn("second message")
+
+	// then each record is stored as a line
+	assert.Equal(t, []string{
+		"level=INFO msg=first count=1",
+		`level=WARN msg="second message"`,
+	}, handler.Buffer.Slice())
+}
+
+func Test_memlog_handler_respects_level(t *testing.T) {
+	handler := NewMemLogHandler(10, &slog.HandlerOptions{Level: slog.LevelWarn, ReplaceAttr: noTime})
+	logger := slog.New(handler)
+
+	logger.Info("ignored")
+	logger.Error("stored")
+
+	assert.Equal(t, []string{"level=ERROR msg=stored"}, handler.Buffer.Slice())
+}
+
+func Test_memlog_handler_with_attrs_and_group(t *testing.T) {
+	// given a logger with attributes and a group
+	handler := NewMemLogHandler(10, &slog.HandlerOptions{ReplaceAttr: noTime})
+	logger := slog.New(handler).With("service", "api").WithGroup("req")
+
+	// when messages are logged by derived and original loggers
+	logger.Info("handled", "status", 200)
+	slog.New(handler).Info("plain")
+
+	// then attributes are applied only to the derived logger
+	// and all records share the same buffer
+	assert.Equal(t, []string{
+		"level=INFO msg=handled service=api req.status=200",
+		"level=INFO msg=plain",
+	}, handler.Buffer.Slice())
+}
+
+func Test_memlog_handler_is_bounded(t *testing.T) {
+	handler := NewMemLogHandler(2, &slog.HandlerOptions{ReplaceAttr: noTime})
+	logger := slog.New(handler)
+
+	logger.Info("one")
+	logger.Info("two")
+	logger.Info("three")
+
+	assert.Equal(t, []string{"level=INFO msg=two", "level=INFO msg=three"}, handler.Buffer.Slice())
+}
@@ -0,0 +1,122 @@
+package memlog
+
+// Snapshot is a copy of the entries in a MemLog at a point in
+// time, ordered from oldest item to the newest.  Snapshots taken
+// with MemLog.Snapshot also record the sequence number of each
+// entry so DiffSnapshots can match entries exactly.  A Snapshot
+// built directly from a slice of entries, or decoded from JSON,
+// is compared by position instead.
+type Snapshot[T any] struct {
+	Entries []T
+	seqs    []uint64
+	source  any
+}
+
+// SnapshotDiff describes how a log changed between two
+// snapshots.  Appended and Evicted are ordered from oldest
+// item to the newest.
+type SnapshotDiff[T any] struct {
+	Appended  []T
+	Evicted   []T
+	Unchanged int
+}
+
+// Snapshot returns a copy of the entries currently in the log
+// that can later be compared with DiffSnapshots.
+func (m *MemLog[T]) Snapshot() Snapshot[T] {
+	m.rlock()
+	defer m.runlock()
+
+	n := m.entries.len()
+	s := Snapshot[T]{
+		Entries: make([]T, n),
+		seqs:    make([]uint64, n),
+		source:  m,
+	}
+	for i := 0; i < n; i++ {
+		entry := m.entries.at(i)
+		s.Entries[i] = entry.value
+		s.seqs[i] = entry.seq
+	}
+
+	return s
+}
+
+// DiffSnapshots returns the entries appended and evicted between
+// before and after, along with the number of entries present in
+// both.
+//
+// When both snapshots were taken from the same log entries are
+// matched by sequence number, so repeated values and entries
+// removed from the middle of the log are reported accurately.
+// Otherwise the longest run of entries at the end of before that
+// also begins after is treated as unchanged.
+func DiffSnapshots[T comparable](before, after Snapshot[T]) SnapshotDiff[T] {
+	if before.sequenced() && after.sequenced() && before.source == after.source {
+		return diffBySeq(before, after)
+	}
+	return diffByPosition(before, after)
+}
+
+// sequenced reports whether s holds a sequence
+// number for every entry.
+func (s Snapshot[T]) sequenced() bool {
+	return s.source != nil && len(s.seqs) == len(s.Entries)
+}
+
+// diffBySeq compares snapshots using the sequence
+// number of each entry.
+func diffBySeq[T any](before, after Snapshot[T]) SnapshotDiff[T] {
+	kept := make(map[uint64]struct{}, len(after.seqs))
+	for _, seq := range after.seqs {
+		kept[seq] = struct{}{}
+	}
+
+	var diff SnapshotDiff[T]
+	existing := make(map[uint64]struct{}, len(before.seqs))
+	for i, seq := range before.seqs {
+		existing[seq] = struct{}{}
+		if _, ok := kept[seq]; !ok {
+			diff.Evicted = append(diff.Evicted, before.Entries[i])
+		}
+	}
+
+	for i, seq := range after.seqs {
+		if _, ok := existing[seq]; ok {
+			diff.Unchanged++
+		} else {
+			diff.Appended = append(diff.Appended, after.Entries[i])
+		}
+	}
+
+	return diff
+}
+
+// diffByPosition compares snapshots by finding the longest
+// suffix of before that is also a prefix of after.
+func diffByPosition[T comparable](before, after Snapshot[T]) SnapshotDiff[T] {
+	overlap := min(len(before.Entries), len(after.Entries))
+	for ; overlap > 0; overlap-- {
+		if equalEntries(before.Entries[len(before.Entries)-overlap:], after.Entries[:overlap]) {
+			break
+		}
+	}
+
+	var diff SnapshotDiff[T]
+	diff.Evicted = append(diff.Evicted, before.Entries[:len(before.Entries)-overlap]...)
+	diff.Appended = append(diff.Appended, after.Entries[overlap:]...)
+	diff.Unchanged = overlap
+
+	return diff
+}
+
+// equalEntries reports whether a and b hold
+// the same entries in the same order.
+func equalEntries[T comparable](a, b []T) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
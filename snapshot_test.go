@@ -0,0 +1,225 @@
+package memlog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_snapshot_copies_entries(t *testing.T) {
+	// given a snapshot of a log
+	log := NewMemLog[int](3)
+	log.Append(1)
+	log.Append(2)
+	snap := log.Snapshot()
+
+	// when the log changes
+	log.Append(3)
+	log.Append(4)
+
+	// then the snapshot is unaffected
+	assert.Equal(t, []int{1, 2}, snap.Entries)
+}
+
+func Test_diff_snapshots_overlapping(t *testing.T) {
+	// given a log that evicts some entries between snapshots
+	log := NewMemLog[int](4)
+	for i := 1; i <= 4; i++ {
+		log.Append(i)
+	}
+	before := log.Snapshot()
+	log.Append(5)
+	log.Append(6)
+	after := log.Snapshot()
+
+	// when the snapshots are compared
+	diff := DiffSnapshots(before, after)
+
+	// then the new and aged out entries are reported
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended:  []int{5, 6},
+		Evicted:   []int{1, 2},
+		Unchanged: 2,
+	}, diff)
+}
+
+func Test_diff_snapshots_disjoint(t *testing.T) {
+	// given a log that wraps completely between snapshots
+	log := NewMemLog[int](3)
+	for i := 1; i <= 3; i++ {
+		log.Append(i)
+	}
+	before := log.Snapshot()
+	for i := 4; i <= 7; i++ {
+		log.Append(i)
+	}
+	after := log.Snapshot()
+
+	// when the snapshots are compared
+	diff := DiffSnapshots(before, after)
+
+	// then every entry is new and every old entry was evicted
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended:  []int{5, 6, 7},
+		Evicted:   []int{1, 2, 3},
+		Unchanged: 0,
+	}, diff)
+}
+
+func Test_diff_snapshots_identical(t *testing.T) {
+	log := NewMemLog[int](3)
+	log.Append(1)
+	log.Append(2)
+	snap := log.Snapshot()
+
+	diff := DiffSnapshots(snap, log.Snapshot())
+
+	assert.Equal(t, SnapshotDiff[int]{Unchanged: 2}, diff)
+}
+
+func Test_diff_snapshots_repeated_values(t *testing.T) {
+	// given a log of equal values that wraps between snapshots
+	log := NewMemLog[string](2)
+	log.Append("retry")
+	log.Append("retry")
+	before := log.Snapshot()
+	log.Append("retry")
+	after := log.Snapshot()
+
+	// then sequence numbers detect the change
+	assert.Equal(t, SnapshotDiff[string]{
+		Appended:  []string{"retry"},
+		Evicted:   []string{"retry"},
+		Unchanged: 1,
+	}, DiffSnapshots(before, after))
+
+	// but positional comparison cannot
+	assert.Equal(t, SnapshotDiff[string]{Unchanged: 2},
+		DiffSnapshots(Snapshot[string]{Entries: before.Entries}, Snapshot[string]{Entries: after.Entries}))
+}
+
+func Test_diff_snapshots_removed_entries(t *testing.T) {
+	// given an entry removed from the middle of the log
+	log := NewMemLog[int](5)
+	for i := 1; i <= 4; i++ {
+		log.Append(i)
+	}
+	before := log.Snapshot()
+	log.RemoveIf(func(item int) bool { return item == 2 })
+	log.Append(5)
+
+	// when the snapshots are compared
+	diff := DiffSnapshots(before, log.Snapshot())
+
+	// then the removed entry is reported as evicted
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended:  []int{5},
+		Evicted:   []int{2},
+		Unchanged: 3,
+	}, diff)
+}
+
+func Test_diff_snapshots_cleared(t *testing.T) {
+	// given a log cleared and refilled with the same values
+	log := NewMemLog[int](3)
+	log.Append(1)
+	log.Append(2)
+	before := log.Snapshot()
+	log.Clear()
+	log.Append(1)
+	log.Append(2)
+
+	// then the entries are new
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended: []int{1, 2},
+		Evicted:  []int{1, 2},
+	}, DiffSnapshots(before, log.Snapshot()))
+}
+
+func Test_diff_snapshots_positional(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after []int
+		want          SnapshotDiff[int]
+	}{
+		{"empty", nil, nil, SnapshotDiff[int]{}},
+		{"from empty", nil, []int{1, 2}, SnapshotDiff[int]{Appended: []int{1, 2}}},
+		{"to empty", []int{1, 2}, nil, SnapshotDiff[int]{Evicted: []int{1, 2}}},
+		{"identical", []int{1, 2, 3}, []int{1, 2, 3}, SnapshotDiff[int]{Unchanged: 3}},
+		{"overlapping", []int{1, 2, 3, 4}, []int{3, 4, 5, 6}, SnapshotDiff[int]{
+			Appended: []int{5, 6}, Evicted: []int{1, 2}, Unchanged: 2,
+		}},
+		{"disjoint", []int{1, 2, 3}, []int{4, 5, 6}, SnapshotDiff[int]{
+			Appended: []int{4, 5, 6}, Evicted: []int{1, 2, 3},
+		}},
+		{"appended only", []int{1, 2}, []int{1, 2, 3}, SnapshotDiff[int]{
+			Appended: []int{3}, Unchanged: 2,
+		}},
+		{"longest overlap", []int{1, 2, 1, 2}, []int{1, 2, 1, 2, 3}, SnapshotDiff[int]{
+			Appended: []int{3}, Unchanged: 4,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := Snapshot[int]{Entries: tt.before}
+			after := Snapshot[int]{Entries: tt.after}
+
+			assert.Equal(t, tt.want, DiffSnapshots(before, after))
+		})
+	}
+}
+
+func Test_diff_snapshots_different_logs(t *testing.T) {
+	// given snapshots of two logs with the same sequence numbers
+	a := NewMemLog[int](3)
+	a.Append(1)
+	a.Append(2)
+	b := NewMemLog[int](3)
+	b.Append(2)
+	b.Append(3)
+
+	// then they are compared by position
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended:  []int{3},
+		Evicted:   []int{1},
+		Unchanged: 1,
+	}, DiffSnapshots(a.Snapshot(), b.Snapshot()))
+}
+
+func Test_diff_snapshots_json(t *testing.T) {
+	log := NewMemLog[string](2)
+	log.Append("a")
+	before := log.Snapshot()
+	log.Append("b")
+	log.Append("c")
+
+	data, err := json.Marshal(DiffSnapshots(before, log.Snapshot()))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Appended":["b","c"],"Evicted":["a"],"Unchanged":0}`, string(data))
+}
+
+func Test_diff_snapshots_sorted_log(t *testing.T) {
+	// given a sorted log where new entries are inserted between old ones
+	log := NewSortedLog(4, func(a, b int) bool { return a < b })
+	log.Append(10)
+	log.Append(30)
+	log.Append(50)
+	before := log.Snapshot()
+	log.Append(20)
+	log.Append(40)
+	after := log.Snapshot()
+
+	// when the snapshots are compared
+	diff := DiffSnapshots(before, after)
+
+	// then entries are matched regardless of their position
+	assert.Equal(t, []int{20, 30, 40, 50}, after.Entries)
+	assert.Equal(t, SnapshotDiff[int]{
+		Appended:  []int{20, 40},
+		Evicted:   []int{10},
+		Unchanged: 2,
+	}, diff)
+}
@@ -0,0 +1,42 @@
+package memlog
+
+import "sort"
+
+// NewSortedLog returns a new MemLog that keeps its entries
+// sorted using less rather than in the order they were
+// appended, so that Slice returns them in sorted order.  Each
+// entry is inserted at its position using a binary search and
+// equal entries are ordered from oldest to newest.
+//
+// Once the log holds size entries, a new entry replaces the
+// smallest entry only if it sorts after it; otherwise the new
+// entry is discarded.  Sort and Replace should not be used on
+// a sorted log as they may break the ordering.
+//
+// Each entry keeps the sequence number it was appended with, so
+// a Cursor reads a sorted log in the order entries were appended
+// and DiffSnapshots matches entries regardless of their position.
+func NewSortedLog[T any](size int, less func(a, b T) bool) *MemLog[T] {
+	m := NewMemLog[T](size)
+	m.order = less
+	return m
+}
+
+// insertSorted adds item at its sorted position, evicting the
+// smallest entry or discarding item if the log is full.  The
+// caller must hold the lock.
+func (m *MemLog[T]) insertSorted(item T) {
+	if m.entries.len() >= m.size {
+		if !m.order(m.entries.at(0).value, item) {
+			m.discard(item)
+			return
+		}
+		m.evict()
+	}
+
+	i := sort.Search(m.entries.len(), func(i int) bool {
+		return m.order(item, m.entries.at(i).value)
+	})
+	m.entries.insert(i, sequenced[T]{seq: m.seq - 1, value: item}, m.size)
+	m.remember(item)
+}
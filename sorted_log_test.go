@@ -0,0 +1,105 @@
+package memlog
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func Test_sorted_log_inserts_in_order(t *testing.T) {
+	// given a sorted log
+	log := NewSortedLog(10, intLess)
+
+	// when entries are appended out of order
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		log.Append(v)
+	}
+
+	// then they are returned in sorted order
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, log.Slice())
+	assert.Equal(t, []int{4, 5}, log.SliceN(2))
+}
+
+func Test_sorted_log_full(t *testing.T) {
+	// given a full sorted log
+	log := NewSortedLog(3, intLess)
+	for _, v := range []int{5, 3, 7} {
+		log.Append(v)
+	}
+
+	// when an entry smaller than every entry is appended
+	log.Append(1)
+
+	// then it is discarded
+	assert.Equal(t, []int{3, 5, 7}, log.Slice())
+
+	// and a larger entry replaces the smallest
+	log.Append(6)
+	assert.Equal(t, []int{5, 6, 7}, log.Slice())
+	assert.Equal(t, uint64(2), log.Stats().TotalEvictions)
+}
+
+func Test_sorted_log_equal_entries_keep_append_order(t *testing.T) {
+	type item struct {
+		key, id int
+	}
+	log := NewSortedLog(10, func(a, b item) bool { return a.key < b.key })
+
+	log.Append(item{2, 1})
+	log.Append(item{1, 2})
+	log.Append(item{2, 3})
+	log.Append(item{1, 4})
+
+	assert.Equal(t, []item{{1, 2}, {1, 4}, {2, 1}, {2, 3}}, log.Slice())
+}
+
+func Test_sorted_log_wraps_ring(t *testing.T) {
+	// given a sorted log whose ring has wrapped
+	log := NewSortedLog(4, intLess)
+	for i := 0; i < 20; i++ {
+		log.Append(i)
+	}
+
+	// when entries are inserted in the middle
+	log.Append(17)
+
+	// then the order is maintained
+	assert.Equal(t, []int{17, 17, 18, 19}, log.Slice())
+}
+
+// The benchmarks append an entry to a full log and then
+// read it in sorted order, as a dashboard polling a
+// top-N log would.
+
+func Benchmark_sorted_log(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	log := NewSortedLog(1000, intLess)
+	for i := 0; i < 1000; i++ {
+		log.Append(r.Int())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Append(r.Int())
+		_ = log.Slice()
+	}
+}
+
+func Benchmark_post_sorted_log(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	log := NewMemLog[int](1000)
+	for i := 0; i < 1000; i++ {
+		log.Append(r.Int())
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Append(r.Int())
+		_ = log.SortedSlice(intLess)
+	}
+}
@@ -0,0 +1,79 @@
+package memlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// sseBuffer is the number of entries that may be queued
+// for a streaming client before new entries are dropped.
+const sseBuffer = 256
+
+// sseHandler streams the contents of a MemLog
+// as Server-Sent Events.
+type sseHandler[T any] struct {
+	log *MemLog[T]
+}
+
+// NewSSEHandler returns an http.Handler that streams log
+// entries as Server-Sent Events.  When a client connects the
+// current contents of the log are sent, followed by each new
+// entry as it is appended, until the client disconnects.  Each
+// entry is sent as a "data: <json>" event.
+func NewSSEHandler[T any](log *MemLog[T]) http.Handler {
+	return &sseHandler[T]{log: log}
+}
+
+// ServeHTTP streams log entries to the client.
+func (h *sseHandler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	backlog, entries, cancel := h.log.SubscribeWithBacklog(sseBuffer)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, entry := range backlog {
+		if err := writeEvent(w, entry); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case entry := <-entries:
+			if err := writeEvent(w, entry); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes entry to w as a single SSE data event.
+func writeEvent[T any](w http.ResponseWriter, entry T) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
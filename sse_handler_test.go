@@ -0,0 +1,87 @@
+package memlog
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readEvent reads the next SSE data event from r.
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	line, err := r.ReadString('\n')
+	assert.NoError(t, err)
+	blank, err := r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "\n", blank)
+
+	return strings.TrimSuffix(strings.TrimPrefix(line, "data: "), "\n")
+}
+
+func Test_sse_handler_streams_backlog_and_new_entries(t *testing.T) {
+	// given a log with existing entries served over SSE
+	log := NewMemLog[string](10)
+	log.Append("item #1")
+	log.Append("item #2")
+
+	server := httptest.NewServer(NewSSEHandler(log))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when a client connects
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+
+	// then the backlog is sent
+	assert.Equal(t, `"item #1"`, readEvent(t, reader))
+	assert.Equal(t, `"item #2"`, readEvent(t, reader))
+
+	// and new entries are streamed as they are appended
+	log.Append("item #3")
+	assert.Equal(t, `"item #3"`, readEvent(t, reader))
+}
+
+func Test_sse_handler_unsubscribes_on_disconnect(t *testing.T) {
+	// given a connected client
+	log := NewMemLog[int](10)
+	log.Append(1)
+
+	server := httptest.NewServer(NewSSEHandler(log))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", readEvent(t, bufio.NewReader(resp.Body)))
+
+	// when the client disconnects
+	cancel()
+	resp.Body.Close()
+
+	// then the subscription is removed
+	assert.Eventually(t, func() bool {
+		return log.Subscribers() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_sse_handler_rejects_other_methods(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewSSEHandler(NewMemLog[string](10)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -1,25 +1,708 @@
 package memlog
 
-import "strings"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// ansiEscape matches complete ANSI CSI escape sequences, such as
+// the SGR color codes "\x1b[32m" and "\x1b[0m", and OSC sequences
+// terminated by BEL or ST.  An ESC that does not begin a complete
+// sequence is not matched.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// trimMode controls how line terminators and surrounding
+// characters are removed from lines before they are stored.
+type trimMode int
+
+const (
+	// trimBoth removes "\r" and "\n" from both ends of each line.
+	trimBoth trimMode = iota
+
+	// trimRight removes only the trailing line terminator.
+	trimRight
+
+	// trimNone stores lines exactly as received minus the
+	// terminating newline.
+	trimNone
+)
+
+// defaultLevelTokens are the level tokens recognized at the
+// start of a line when level parsing is enabled.
+var defaultLevelTokens = map[string]Level{
+	"DEBUG":   LevelDebug,
+	"INFO":    LevelInfo,
+	"WARN":    LevelWarn,
+	"WARNING": LevelWarn,
+	"ERROR":   LevelError,
+}
 
 // StringLog is used to write an internal list of
 // strings to a MemLog[T] structure.
+//
+// When level entries are enabled using WithLevelEntries
+// each stored line is also appended to Levels along with
+// its parsed level.  When stream entries are enabled using
+// WithStreamEntries lines written to a named Stream are
+// appended to Streams instead of Buffer.
 type StringLog struct {
-	Buffer *MemLog[string]
+	Buffer        *MemLog[string]
+	Levels        *MemLog[LevelEntry[string]]
+	Streams       *MemLog[StreamEntry]
+	passthrough   io.Writer
+	stripANSI     bool
+	buffered      bool
+	maxRunes      int
+	maxBytes      int
+	trim          trimMode
+	parseLevels   bool
+	levelEntries  bool
+	trailingNL    bool
+	streamEntries bool
+	internSize    int
+	streams       []*streamWriter
+	closed        bool
+	levelTokens   map[string]Level
+	defaultLvl    Level
+	minLevel      atomic.Int32
+	timeLayout    string
+	prefix        string
+	pending       lineBuffer
+	now           func() time.Time
+	locker        sync.Mutex
+}
+
+var (
+	_ expvar.Var     = (*StringLog)(nil)
+	_ io.WriteCloser = (*StringLog)(nil)
+)
+
+// ErrClosed is returned when writing to a log that has been closed.
+var ErrClosed = errors.New("memlog: write to closed log")
+
+// StringLogOption configures optional behavior of a StringLog.
+type StringLogOption func(*StringLog)
+
+// WithStripANSI removes ANSI terminal escape sequences, such as
+// color codes, from each entry before it is stored.  Incomplete
+// sequences and bare ESC characters are left intact.  When combined
+// with WithLineBuffering, sequences split across Write calls are
+// removed once the line is complete.
+func WithStripANSI() StringLogOption {
+	return func(s *StringLog) {
+		s.stripANSI = true
+	}
+}
+
+// WithLineBuffering causes Write to accumulate partial lines
+// until a newline is received rather than treating each call
+// to Write as a complete line.  Use Flush to store a trailing
+// line that has no terminating newline.
+func WithLineBuffering() StringLogOption {
+	return func(s *StringLog) {
+		s.buffered = true
+	}
+}
+
+// WithMaxLineLength truncates entries longer than max runes to
+// max runes followed by "..." so that a single very long line
+// cannot dominate the buffer.
+func WithMaxLineLength(max int) StringLogOption {
+	return func(s *StringLog) {
+		s.maxRunes = max
+	}
+}
+
+// WithMaxLineBytes truncates entries longer than max bytes at
+// the nearest preceding rune boundary and appends a marker such
+// as "…[truncated 2048 bytes]" recording how much was removed.
+// The marker is not counted against max.
+func WithMaxLineBytes(max int) StringLogOption {
+	return func(s *StringLog) {
+		s.maxBytes = max
+	}
+}
+
+// WithTimestamps prefixes each entry with the time of the
+// Write call that produced it, formatted using layout.  All
+// lines produced by a single Write share the same timestamp.
+func WithTimestamps(layout string) StringLogOption {
+	return func(s *StringLog) {
+		s.timeLayout = layout
+	}
+}
+
+// WithTrimRightOnly removes only the trailing line terminator
+// from each line, preserving leading newlines and any other
+// leading or trailing whitespace.  By default "\r" and "\n"
+// are trimmed from both ends of each write and each line.
+func WithTrimRightOnly() StringLogOption {
+	return func(s *StringLog) {
+		s.trim = trimRight
+	}
+}
+
+// WithRawLines stores each line exactly as it was received
+// minus the single terminating "\n".  A "\r" preceding the
+// newline is retained.
+func WithRawLines() StringLogOption {
+	return func(s *StringLog) {
+		s.trim = trimNone
+	}
+}
+
+// WithLevelFilter enables parsing of a leading level token,
+// such as "WARN", from each line and discards lines below min.
+// Use SetMinLevel to change the threshold at runtime.
+func WithLevelFilter(min Level) StringLogOption {
+	return func(s *StringLog) {
+		s.parseLevels = true
+		s.minLevel.Store(int32(min))
+	}
+}
+
+// WithLevelTokens replaces the set of recognized level tokens.
+// Tokens are matched case-insensitively against the first
+// word of each line.  The default tokens are DEBUG, INFO,
+// WARN, WARNING and ERROR.
+func WithLevelTokens(tokens map[string]Level) StringLogOption {
+	return func(s *StringLog) {
+		s.levelTokens = make(map[string]Level, len(tokens))
+		for token, level := range tokens {
+			s.levelTokens[strings.ToUpper(token)] = level
+		}
+	}
+}
+
+// WithDefaultLevel sets the level assigned to lines that do
+// not begin with a recognized level token.  The default is
+// LevelInfo.
+func WithDefaultLevel(level Level) StringLogOption {
+	return func(s *StringLog) {
+		s.defaultLvl = level
+	}
+}
+
+// WithLevelEntries enables level parsing and causes each
+// stored line to also be appended, with its parsed level,
+// to the Levels log.
+func WithLevelEntries() StringLogOption {
+	return func(s *StringLog) {
+		s.parseLevels = true
+		s.levelEntries = true
+	}
+}
+
+// WithTrailingNewline causes Text and LastNText to terminate
+// the final line with a newline, as is conventional for text
+// files.
+func WithTrailingNewline() StringLogOption {
+	return func(s *StringLog) {
+		s.trailingNL = true
+	}
+}
+
+// WithStringInterning causes duplicate lines to share memory
+// by interning up to capacity recently seen lines.  See
+// WithInterning.
+func WithStringInterning(capacity int) StringLogOption {
+	return func(s *StringLog) {
+		s.internSize = capacity
+	}
+}
+
+// WithStringClock sets the clock used for timestamps and
+// by the log's buffers.  See WithClock.
+func WithStringClock(c Clock) StringLogOption {
+	return func(s *StringLog) {
+		s.now = c.Now
+	}
 }
 
 // NewStringLog returns a StringLog initialized
 // with a maximum of size entries.
-func NewStringLog(size int) *StringLog {
-	return &StringLog{
-		Buffer: NewMemLog[string](size),
+func NewStringLog(size int, opts ...StringLogOption) *StringLog {
+	s := &StringLog{
+		now:         SystemClock.Now,
+		levelTokens: defaultLevelTokens,
+		defaultLvl:  LevelInfo,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	clock := WithClock[string](ClockFunc(s.now))
+	if s.internSize > 0 {
+		s.Buffer = NewMemLog(size, clock, WithInterning(s.internSize))
+	} else {
+		s.Buffer = NewMemLog(size, clock)
 	}
+
+	if s.levelEntries {
+		s.Levels = NewMemLog(size, WithClock[LevelEntry[string]](ClockFunc(s.now)))
+	}
+
+	if s.streamEntries {
+		s.Streams = NewMemLog(size, WithClock[StreamEntry](ClockFunc(s.now)))
+	}
+
+	return s
+}
+
+// NewTeeStringLog returns a StringLog initialized with a
+// maximum of size entries that also forwards everything
+// written to it, unmodified, to passthrough.  This allows
+// output to continue to a destination such as os.Stdout while
+// the most recent lines are kept in memory.
+func NewTeeStringLog(size int, passthrough io.Writer, opts ...StringLogOption) *StringLog {
+	s := NewStringLog(size, opts...)
+	s.passthrough = passthrough
+	return s
 }
 
 // Write provides an implentation of the io.Writer
 // interface that writes the output from the stream
-// into a set of strings inside a MemLog buffer
+// into a set of strings inside a MemLog buffer.
+// Each line in p is stored as a separate entry.
+//
+// When the StringLog was created with NewTeeStringLog the
+// original bytes are also written to the passthrough writer
+// and its results are returned.  The entry is stored in
+// the buffer even if the passthrough write fails.
 func (s *StringLog) Write(p []byte) (n int, err error) {
-	s.Buffer.Append(strings.Trim(string(p), "\r\n"))
+	if err := s.write(string(p)); err != nil {
+		return 0, err
+	}
+
+	if s.passthrough != nil {
+		return s.passthrough.Write(p)
+	}
+
 	return len(p), nil
 }
+
+// WriteString provides an implementation of the io.StringWriter
+// interface.  It behaves exactly like Write but avoids
+// converting str to a byte slice.
+func (s *StringLog) WriteString(str string) (n int, err error) {
+	if err := s.write(str); err != nil {
+		return 0, err
+	}
+
+	if s.passthrough != nil {
+		return io.WriteString(s.passthrough, str)
+	}
+
+	return len(str), nil
+}
+
+// ReadFrom provides an implementation of the io.ReaderFrom
+// interface that reads r until EOF or an error, storing each
+// line as an entry.  Lines may be of any length and a final
+// line without a terminating newline is stored when reading
+// stops.  Because the buffer is bounded, reading a large file
+// keeps only its last lines without holding the whole file in
+// memory.
+//
+// Lines are split and trimmed as if everything read had been
+// passed to a single call to Write, continuing any partial line
+// left by earlier buffered writes, except that nothing is stored
+// if only line terminators are read.
+//
+// When the StringLog was created with NewTeeStringLog the data
+// read is also written to the passthrough writer.  ReadFrom
+// returns the number of bytes read and the first error other
+// than io.EOF encountered.
+func (s *StringLog) ReadFrom(r io.Reader) (n int64, err error) {
+	s.locker.Lock()
+	if s.closed {
+		s.locker.Unlock()
+		return 0, ErrClosed
+	}
+	var partial lineBuffer
+	partial.WriteString(s.pending.take())
+	s.locker.Unlock()
+
+	// with the default trim mode Write ignores line terminators
+	// at the start and end of its input, so blank lines are only
+	// stored once a later line shows they are not at the end
+	started := partial.Len() > 0
+	blanks := 0
+	emit := func(line string) {
+		if s.trim == trimBoth && strings.Trim(line, "\r") == "" {
+			if started {
+				blanks++
+			}
+			return
+		}
+		for ; blanks > 0; blanks-- {
+			s.store("", "")
+		}
+		started = true
+		s.store("", s.trimLine(line))
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		count, readErr := r.Read(buf)
+		if count > 0 {
+			n += int64(count)
+
+			s.locker.Lock()
+			s.stamp()
+			partial.write(string(buf[:count]), emit)
+			s.locker.Unlock()
+
+			if s.passthrough != nil && err == nil {
+				_, err = s.passthrough.Write(buf[:count])
+			}
+		}
+
+		if readErr != nil {
+			if readErr != io.EOF && err == nil {
+				err = readErr
+			}
+			break
+		}
+	}
+
+	if partial.Len() > 0 {
+		s.locker.Lock()
+		emit(partial.take())
+		s.locker.Unlock()
+	}
+
+	return n, err
+}
+
+// write stores the lines in chunk.
+func (s *StringLog) write(chunk string) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	s.stamp()
+	if s.buffered {
+		s.appendBuffered(chunk)
+	} else {
+		s.appendLines("", chunk)
+	}
+
+	return nil
+}
+
+// Flush stores any buffered partial line as a final entry.
+// It has no effect unless line buffering is enabled.
+func (s *StringLog) Flush() error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.flush()
+	return nil
+}
+
+// Close flushes any buffered partial line and marks the log
+// closed so that further writes return ErrClosed.  The entries
+// remain available and the passthrough writer, if any, is not
+// closed.  Calling Close more than once has no effect.
+func (s *StringLog) Close() error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.flush()
+	s.closed = true
+	return nil
+}
+
+// isClosed reports whether Close has been called.
+func (s *StringLog) isClosed() bool {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	return s.closed
+}
+
+// flush stores the pending partial lines of the log and
+// its streams.  The caller must hold the lock.
+func (s *StringLog) flush() {
+	s.stamp()
+
+	if s.pending.Len() > 0 {
+		s.store("", s.trimLine(s.pending.take()))
+	}
+
+	for _, stream := range s.streams {
+		if stream.pending.Len() > 0 {
+			s.store(stream.name, s.trimLine(stream.pending.take()))
+		}
+	}
+}
+
+// SetMinLevel changes the level below which lines are
+// discarded.  It has no effect unless level parsing was
+// enabled with WithLevelFilter or WithLevelEntries.
+func (s *StringLog) SetMinLevel(level Level) {
+	s.minLevel.Store(int32(level))
+}
+
+// Lines returns all current entries joined by "\n".
+func (s *StringLog) Lines() string {
+	return s.LinesDelimited("\n")
+}
+
+// LinesDelimited returns all current entries joined by sep.
+func (s *StringLog) LinesDelimited(sep string) string {
+	return strings.Join(s.Buffer.Slice(), sep)
+}
+
+// String returns the current entries as a JSON array.  This
+// implements the expvar.Var interface so that the log can be
+// published with expvar.Publish and viewed at /debug/vars.
+func (s *StringLog) String() string {
+	data, err := json.Marshal(s.Buffer.Slice())
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// Text returns all current entries joined by "\n".
+func (s *StringLog) Text() string {
+	return s.LastNText(allElements)
+}
+
+// LastNText returns the last n entries joined by "\n".
+// The result is built with a single allocation while holding
+// the buffer's lock once.
+func (s *StringLog) LastNText(n int) string {
+	m := s.Buffer
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	size, count := 0, 0
+	m.forEachN(n, func(line string) {
+		size += len(line) + 1
+		count++
+	})
+
+	if count == 0 {
+		return ""
+	}
+
+	if !s.trailingNL {
+		size--
+	}
+
+	var sb strings.Builder
+	sb.Grow(size)
+	first := true
+	m.forEachN(n, func(line string) {
+		if !first {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(line)
+		first = false
+	})
+
+	if s.trailingNL {
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// appendLines stores each line in chunk, written to the named
+// stream, as a separate entry.  Empty lines within chunk are
+// preserved.  By default line terminators at the start and end
+// of chunk are ignored; otherwise only the final newline is.
+func (s *StringLog) appendLines(name, chunk string) {
+	if s.trim == trimBoth {
+		chunk = strings.Trim(chunk, "\r\n")
+	} else {
+		chunk = strings.TrimSuffix(chunk, "\n")
+	}
+
+	chunk = splitLines(chunk, func(line string) {
+		s.store(name, s.trimLine(line))
+	})
+
+	s.store(name, s.trimLine(chunk))
+}
+
+// appendBuffered stores each complete line in chunk, carrying
+// any text after the last newline forward to the next call.
+func (s *StringLog) appendBuffered(chunk string) {
+	s.pending.write(chunk, func(line string) {
+		s.store("", s.trimLine(line))
+	})
+}
+
+// stamp records the timestamp prefix used for entries
+// stored by the current operation.
+func (s *StringLog) stamp() {
+	if s.timeLayout != "" {
+		s.prefix = s.now().Format(s.timeLayout) + " "
+	}
+}
+
+// store applies the configured transformations to line,
+// written to the named stream, and appends the result to the
+// buffer.  Lines written directly to the log have no name.
+func (s *StringLog) store(name, line string) {
+	if s.stripANSI {
+		line = ansiEscape.ReplaceAllString(line, "")
+	}
+
+	level := s.defaultLvl
+	if s.parseLevels {
+		level = s.parseLevel(line)
+		if level < Level(s.minLevel.Load()) {
+			return
+		}
+	}
+
+	if s.maxRunes > 0 {
+		line = truncateRunes(line, s.maxRunes)
+	}
+
+	if s.maxBytes > 0 {
+		line = truncateBytes(line, s.maxBytes)
+	}
+
+	if name != "" && s.Streams == nil {
+		line = "[" + name + "] " + line
+	}
+
+	if s.prefix != "" {
+		line = s.prefix + line
+	}
+
+	if name != "" && s.Streams != nil {
+		s.Streams.Append(StreamEntry{Name: name, Line: line})
+		return
+	}
+
+	s.Buffer.Append(line)
+
+	if s.Levels != nil {
+		s.Levels.Append(LevelEntry[string]{Level: level, Value: line})
+	}
+}
+
+// parseLevel returns the level named by the first word
+// of line or the default level if it is not recognized.
+func (s *StringLog) parseLevel(line string) Level {
+	token := strings.TrimLeft(line, " \t")
+	if idx := strings.IndexAny(token, " \t"); idx >= 0 {
+		token = token[:idx]
+	}
+
+	if level, ok := s.levelTokens[strings.ToUpper(token)]; ok {
+		return level
+	}
+
+	return s.defaultLvl
+}
+
+// splitLines calls fn with each newline terminated line in
+// data, excluding the newline, and returns any text following
+// the last newline.
+func splitLines(data string, fn func(line string)) (remainder string) {
+	for {
+		idx := strings.IndexByte(data, '\n')
+		if idx < 0 {
+			return data
+		}
+		fn(data[:idx])
+		data = data[idx+1:]
+	}
+}
+
+// lineBuffer holds a partial line carried
+// forward between writes.
+type lineBuffer struct {
+	bytes.Buffer
+}
+
+// write calls fn with each line completed by chunk, excluding
+// the newline, and keeps any text following the last newline.
+// Text is only copied when a line spans several writes.
+func (b *lineBuffer) write(chunk string, fn func(line string)) {
+	for {
+		idx := strings.IndexByte(chunk, '\n')
+		if idx < 0 {
+			b.WriteString(chunk)
+			return
+		}
+
+		line := chunk[:idx]
+		if b.Len() > 0 {
+			b.WriteString(line)
+			line = b.take()
+		}
+		fn(line)
+		chunk = chunk[idx+1:]
+	}
+}
+
+// take returns the buffered text and empties the buffer.
+func (b *lineBuffer) take() string {
+	text := b.String()
+	b.Reset()
+	return text
+}
+
+// trimLine removes line terminators from line according
+// to the configured trim mode.
+func (s *StringLog) trimLine(line string) string {
+	switch s.trim {
+	case trimRight:
+		return strings.TrimRight(line, "\r\n")
+	case trimNone:
+		return line
+	default:
+		return strings.Trim(line, "\r\n")
+	}
+}
+
+// truncateRunes shortens line to max runes, appending "..."
+// when any runes were removed.
+func truncateRunes(line string, max int) string {
+	count := 0
+	for idx := range line {
+		if count == max {
+			return line[:idx] + "..."
+		}
+		count++
+	}
+	return line
+}
+
+// truncateBytes shortens line to at most max bytes without
+// splitting a rune, appending a marker with the number of
+// bytes removed.
+func truncateBytes(line string, max int) string {
+	if len(line) <= max {
+		return line
+	}
+
+	cut := max
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s…[truncated %d bytes]", line[:cut], len(line)-cut)
+}
@@ -0,0 +1,51 @@
+package memlog
+
+import "io"
+
+// lineReader is an io.Reader that streams a snapshot
+// of lines separated by newlines.
+type lineReader struct {
+	lines      []string
+	line       int
+	offset     int
+	trailingNL bool
+}
+
+// NewReader returns an io.Reader over a snapshot of the current
+// entries, producing the same bytes as Text.  Lines are copied
+// into the caller's buffer as it is read rather than being
+// concatenated up front, and entries appended after NewReader
+// returns do not affect the reader.
+func (s *StringLog) NewReader() io.Reader {
+	return &lineReader{
+		lines:      s.Buffer.Slice(),
+		trailingNL: s.trailingNL,
+	}
+}
+
+// Read copies the next portion of the snapshot into p.
+func (r *lineReader) Read(p []byte) (n int, err error) {
+	for n < len(p) && r.line < len(r.lines) {
+		current := r.lines[r.line]
+
+		if r.offset < len(current) {
+			copied := copy(p[n:], current[r.offset:])
+			r.offset += copied
+			n += copied
+			continue
+		}
+
+		if r.line < len(r.lines)-1 || r.trailingNL {
+			p[n] = '\n'
+			n++
+		}
+		r.line++
+		r.offset = 0
+	}
+
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+
+	return n, nil
+}
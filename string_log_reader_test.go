@@ -0,0 +1,73 @@
+package memlog
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readInChunks reads r to EOF using a buffer of size bytes.
+func readInChunks(t *testing.T, r io.Reader, size int) string {
+	t.Helper()
+
+	var sb strings.Builder
+	buf := make([]byte, size)
+	for {
+		n, err := r.Read(buf)
+		sb.Write(buf[:n])
+		if err == io.EOF {
+			return sb.String()
+		}
+		assert.NoError(t, err)
+	}
+}
+
+func Test_string_log_reader_matches_text(t *testing.T) {
+	for _, opts := range [][]StringLogOption{nil, {WithTrailingNewline()}} {
+		// given a log with lines of varying length
+		sl := NewStringLog(100, opts...)
+		sl.Write([]byte("first\n\nthird line is longer\nx\n"))
+
+		// then reading with small buffers reproduces the text
+		for _, size := range []int{1, 2, 3, 7, 64} {
+			assert.Equal(t, sl.Text(), readInChunks(t, sl.NewReader(), size))
+		}
+	}
+}
+
+func Test_string_log_reader_is_a_snapshot(t *testing.T) {
+	// given a reader over a log
+	sl := NewStringLog(2)
+	sl.Write([]byte("first\nsecond\n"))
+	r := sl.NewReader()
+
+	// when entries are appended afterwards
+	sl.Write([]byte("third\n"))
+
+	// then the reader is unaffected
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "first\nsecond", string(data))
+}
+
+func Test_string_log_reader_empty(t *testing.T) {
+	data, err := io.ReadAll(NewStringLog(10).NewReader())
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func Test_string_log_reader_with_scanner(t *testing.T) {
+	sl := NewStringLog(10)
+	sl.Write([]byte("a\nb\nc\n"))
+
+	var lines []string
+	scanner := bufio.NewScanner(sl.NewReader())
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, lines)
+}
@@ -0,0 +1,84 @@
+package memlog
+
+import "io"
+
+// StreamEntry is a line written to a named
+// stream of a StringLog.
+type StreamEntry struct {
+	Name string
+	Line string
+}
+
+// WithStreamEntries causes lines written to a named Stream to
+// be stored as StreamEntry values in the Streams log rather
+// than as tagged lines in Buffer.
+func WithStreamEntries() StringLogOption {
+	return func(s *StringLog) {
+		s.streamEntries = true
+	}
+}
+
+// streamWriter writes lines to a StringLog on
+// behalf of a named stream.
+type streamWriter struct {
+	log     *StringLog
+	name    string
+	pending lineBuffer
+}
+
+// Stream returns an io.Writer whose lines are stored in this
+// log tagged with name.  By default each line is stored in
+// Buffer with a prefix such as "[stderr] "; with
+// WithStreamEntries they are stored in Streams instead.
+//
+// All streams share the same bounded log so the order in which
+// lines from different streams arrive is preserved, which makes
+// it possible to capture a subprocess's stdout and stderr
+// together.  Each stream has its own partial line buffer when
+// WithLineBuffering is used.  Stream output is not written to
+// the passthrough writer of a tee log.
+//
+// Calling Stream again with the same name returns the same
+// writer, so streams may be requested as often as needed.
+// Stream panics if name is empty, as its lines could not be
+// told apart from lines written to the log directly.
+func (s *StringLog) Stream(name string) io.Writer {
+	if name == "" {
+		panic("memlog: Stream requires a name")
+	}
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	for _, w := range s.streams {
+		if w.name == name {
+			return w
+		}
+	}
+
+	w := &streamWriter{log: s, name: name}
+	s.streams = append(s.streams, w)
+	return w
+}
+
+// Write stores the lines in p tagged with the stream's name.
+func (w *streamWriter) Write(p []byte) (n int, err error) {
+	s := w.log
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if s.closed {
+		return 0, ErrClosed
+	}
+
+	s.stamp()
+	if s.buffered {
+		w.pending.write(string(p), func(line string) {
+			s.store(w.name, s.trimLine(line))
+		})
+	} else {
+		s.appendLines(w.name, string(p))
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,118 @@
+package memlog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_string_log_stream_tags_lines(t *testing.T) {
+	// given a log with stdout and stderr streams
+	sl := NewStringLog(10)
+	stdout := sl.Stream("stdout")
+	stderr := sl.Stream("stderr")
+
+	// when lines are written to each
+	stdout.Write([]byte("starting\n"))
+	stderr.Write([]byte("warning: low disk\n"))
+	stdout.Write([]byte("done\n"))
+
+	// then they are stored in order with a tag
+	assert.Equal(t, []string{
+		"[stdout] starting",
+		"[stderr] warning: low disk",
+		"[stdout] done",
+	}, sl.Buffer.Slice())
+}
+
+func Test_string_log_stream_entries(t *testing.T) {
+	// given a log storing structured stream entries
+	sl := NewStringLog(10, WithStreamEntries(), WithLineBuffering())
+	stdout := sl.Stream("stdout")
+	stderr := sl.Stream("stderr")
+
+	// when partial lines are interleaved across streams
+	stdout.Write([]byte("hel"))
+	stderr.Write([]byte("oops\n"))
+	stdout.Write([]byte("lo\nbye"))
+	sl.Flush()
+
+	// then each stream's lines are assembled separately
+	assert.Equal(t, []StreamEntry{
+		{Name: "stderr", Line: "oops"},
+		{Name: "stdout", Line: "hello"},
+		{Name: "stdout", Line: "bye"},
+	}, sl.Streams.Slice())
+	assert.Zero(t, sl.Buffer.Len())
+}
+
+func Test_string_log_stream_after_close(t *testing.T) {
+	sl := NewStringLog(10, WithLineBuffering())
+	stderr := sl.Stream("stderr")
+	stderr.Write([]byte("partial"))
+
+	sl.Close()
+	_, err := stderr.Write([]byte("more\n"))
+
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Equal(t, []string{"[stderr] partial"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_stream_reuses_writer(t *testing.T) {
+	// given a stream requested several times
+	sl := NewStringLog(10, WithLineBuffering())
+	first := sl.Stream("stderr")
+	first.Write([]byte("par"))
+
+	// when it is requested again
+	second := sl.Stream("stderr")
+	second.Write([]byte("tial\n"))
+
+	// then the same writer and partial line are used
+	assert.Same(t, first, second)
+	assert.Len(t, sl.streams, 1)
+	assert.Equal(t, []string{"[stderr] partial"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_stream_requires_name(t *testing.T) {
+	sl := NewStringLog(10)
+
+	assert.Panics(t, func() { sl.Stream("") })
+}
+
+func Test_string_log_stream_concurrent_writes(t *testing.T) {
+	// given a bounded log with two streams
+	size := 50
+	sl := NewStringLog(size, WithLineBuffering())
+	streams := []string{"stdout", "stderr"}
+
+	// when both streams are written concurrently
+	var wg sync.WaitGroup
+	for _, name := range streams {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			w := sl.Stream(name)
+			for i := 0; i < 1000; i++ {
+				fmt.Fprintf(w, "%s line %d\n", name, i)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	// then the log is bounded and each line is tagged correctly
+	lines := sl.Buffer.Slice()
+	assert.Len(t, lines, size)
+	for _, line := range lines {
+		var matched bool
+		for _, name := range streams {
+			if strings.HasPrefix(line, "["+name+"] "+name+" line ") {
+				matched = true
+			}
+		}
+		assert.True(t, matched, line)
+	}
+}
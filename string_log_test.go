@@ -1,7 +1,16 @@
 package memlog
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,3 +42,842 @@ func Test_write_string_log_multiple_lines(t *testing.T) {
 	assert.Equal(t, "Test message 2", sl.Buffer.Slice()[1])
 	assert.Equal(t, "Test message 3", sl.Buffer.Slice()[2])
 }
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+type partialWriter struct {
+	max int
+}
+
+func (w partialWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		return w.max, io.ErrShortWrite
+	}
+	return len(p), nil
+}
+
+func Test_tee_string_log_forwards_bytes_unmodified(t *testing.T) {
+	// given a tee log writing to an output buffer
+	var out strings.Builder
+	sl := NewTeeStringLog(100, &out)
+
+	// when lines are written
+	input := []string{"line 1\n", "line 2\r\n", "\nline 3"}
+	for _, line := range input {
+		n, err := sl.Write([]byte(line))
+		assert.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+
+	// then the output is byte-identical and the buffer is trimmed
+	assert.Equal(t, strings.Join(input, ""), out.String())
+	assert.Equal(t, []string{"line 1", "line 2", "line 3"}, sl.Buffer.Slice())
+}
+
+func Test_tee_string_log_captures_when_passthrough_fails(t *testing.T) {
+	// given a tee log with a failing writer
+	sl := NewTeeStringLog(100, failingWriter{})
+
+	// when a line is written
+	n, err := sl.Write([]byte("Test message\n"))
+
+	// then the error is returned but the line is still captured
+	assert.Error(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, []string{"Test message"}, sl.Buffer.Slice())
+}
+
+func Test_tee_string_log_reports_short_write(t *testing.T) {
+	// given a tee log with a writer that accepts at most 4 bytes
+	sl := NewTeeStringLog(100, partialWriter{max: 4})
+
+	// when a longer line is written
+	n, err := sl.Write([]byte("Test message\n"))
+
+	// then the short write is propagated
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []string{"Test message"}, sl.Buffer.Slice())
+}
+
+func Test_tee_string_log_bytes_buffer_keeps_full_output(t *testing.T) {
+	// given a small tee log writing to a bytes.Buffer
+	var out bytes.Buffer
+	sl := NewTeeStringLog(2, &out)
+
+	// when more lines are written than the log can hold
+	for i := 1; i <= 4; i++ {
+		fmt.Fprintf(sl, "line %d\n", i)
+	}
+
+	// then the buffer keeps all output and the log the most recent lines
+	assert.Equal(t, "line 1\nline 2\nline 3\nline 4\n", out.String())
+	assert.Equal(t, []string{"line 3", "line 4"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_strip_ansi(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "Test message", "Test message"},
+		{"color", "\x1b[32mOK\x1b[0m done", "OK done"},
+		{"bold color", "\x1b[1;31mERROR\x1b[0m: failed", "ERROR: failed"},
+		{"256 color", "\x1b[38;5;208mwarn\x1b[m", "warn"},
+		{"cursor movement", "\x1b[2K\x1b[1Gprogress 50%", "progress 50%"},
+		{"private mode", "\x1b[?25lhidden cursor\x1b[?25h", "hidden cursor"},
+		{"logrus colored", "\x1b[36mINFO\x1b[0m[0000] starting server                               \x1b[36mport\x1b[0m=8080", "INFO[0000] starting server                               port=8080"},
+		{"fatih color", "\x1b[31;1mError:\x1b[0m \x1b[33mconfig not found\x1b[0m", "Error: config not found"},
+		{"hyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"window title", "\x1b]0;title\x07text", "text"},
+		{"bare escape", "a\x1bb", "a\x1bb"},
+		{"trailing escape", "text\x1b", "text\x1b"},
+		{"unterminated csi", "red\x1b[31", "red\x1b[31"},
+		{"unterminated osc", "\x1b]0;title", "\x1b]0;title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewStringLog(100, WithStripANSI())
+			sl.Write([]byte(tt.input + "\n"))
+			assert.Equal(t, tt.want, sl.Buffer.Slice()[0])
+		})
+	}
+}
+
+func Test_string_log_strip_ansi_split_across_writes(t *testing.T) {
+	// given a buffered log that strips escape sequences
+	sl := NewStringLog(100, WithStripANSI(), WithLineBuffering())
+
+	// when a sequence is split across writes
+	sl.Write([]byte("\x1b[3"))
+	sl.Write([]byte("2mgreen\x1b"))
+	sl.Write([]byte("[0m\n"))
+
+	// then the completed line is stripped
+	assert.Equal(t, []string{"green"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_keeps_ansi_by_default(t *testing.T) {
+	sl := NewStringLog(100)
+	sl.Write([]byte("\x1b[32mOK\x1b[0m"))
+	assert.Equal(t, "\x1b[32mOK\x1b[0m", sl.Buffer.Slice()[0])
+}
+
+func Test_buffered_string_log_joins_split_line(t *testing.T) {
+	// given a line buffered log
+	sl := NewStringLog(100, WithLineBuffering())
+
+	// when a line arrives in two writes
+	sl.Write([]byte("Hel"))
+	sl.Write([]byte("lo world\n"))
+
+	// then a single entry is stored
+	assert.Equal(t, []string{"Hello world"}, sl.Buffer.Slice())
+}
+
+func Test_buffered_string_log_joins_line_split_across_three_writes(t *testing.T) {
+	// given a line buffered log
+	sl := NewStringLog(100, WithLineBuffering())
+
+	// when a line arrives in three writes
+	sl.Write([]byte("Test "))
+	assert.Zero(t, sl.Buffer.Len())
+	sl.Write([]byte("mess"))
+	assert.Zero(t, sl.Buffer.Len())
+	sl.Write([]byte("age\r\nnext"))
+
+	// then the completed line is stored and the remainder is held
+	assert.Equal(t, []string{"Test message"}, sl.Buffer.Slice())
+
+	sl.Flush()
+	assert.Equal(t, []string{"Test message", "next"}, sl.Buffer.Slice())
+}
+
+func Test_buffered_string_log_long_line_in_small_writes(t *testing.T) {
+	// given a line buffered log
+	sl := NewStringLog(10, WithLineBuffering())
+	long := strings.Repeat("z", 100*1024)
+
+	// when a long line arrives in many small writes
+	for i := 0; i < len(long); i += 100 {
+		sl.Write([]byte(long[i : i+100]))
+	}
+	sl.Write([]byte("\nafter\n"))
+
+	// then it is stored intact
+	assert.Equal(t, []string{long, "after"}, sl.Buffer.Slice())
+}
+
+func Test_buffered_string_log_without_newline(t *testing.T) {
+	// given a line buffered log
+	sl := NewStringLog(100, WithLineBuffering())
+
+	// when writes never contain a newline
+	sl.Write([]byte("Test "))
+	sl.Write([]byte("message"))
+
+	// then nothing is stored until the log is flushed
+	assert.Zero(t, sl.Buffer.Len())
+	assert.NoError(t, sl.Flush())
+	assert.Equal(t, []string{"Test message"}, sl.Buffer.Slice())
+
+	// and flushing again stores nothing
+	sl.Flush()
+	assert.Equal(t, 1, sl.Buffer.Len())
+}
+
+func Test_string_log_max_line_length(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"shorter than limit", "abc", "abc"},
+		{"exactly at limit", "abcde", "abcde"},
+		{"one over limit", "abcdef", "abcde..."},
+		{"multi-byte at limit", "héllo", "héllo"},
+		{"multi-byte over limit", "日本語のテキスト", "日本語のテ..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewStringLog(100, WithMaxLineLength(5))
+			sl.Write([]byte(tt.input + "\n"))
+			assert.Equal(t, tt.want, sl.Buffer.Slice()[0])
+		})
+	}
+}
+
+func Test_string_log_max_line_length_applies_after_strip_ansi(t *testing.T) {
+	sl := NewStringLog(100, WithStripANSI(), WithMaxLineLength(5))
+	sl.Write([]byte("\x1b[32mabcde\x1b[0m"))
+	assert.Equal(t, "abcde", sl.Buffer.Slice()[0])
+}
+
+func Test_string_log_splits_multi_line_write(t *testing.T) {
+	// given a memlog
+	sl := NewStringLog(100)
+
+	// when a single write contains several lines
+	sl.Write([]byte("line1\nline2\nline3\n"))
+
+	// then each line is stored in order
+	assert.Equal(t, []string{"line1", "line2", "line3"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_splits_lines(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffered bool
+		input    string
+		want     []string
+	}{
+		{"crlf", false, "a\r\nb\r\nc\r\n", []string{"a", "b", "c"}},
+		{"mixed crlf and lf", false, "a\r\nb\nc\r\n", []string{"a", "b", "c"}},
+		{"blank lines in middle", false, "a\n\n\nb\n", []string{"a", "", "", "b"}},
+		{"blank crlf lines in middle", false, "a\r\n\r\nb", []string{"a", "", "b"}},
+		{"buffered mixed", true, "a\r\nb\nc\r\n", []string{"a", "b", "c"}},
+		{"buffered blank lines", true, "a\n\nb\n", []string{"a", "", "b"}},
+		{"buffered carries remainder", true, "a\nb\nc", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []StringLogOption
+			if tt.buffered {
+				opts = append(opts, WithLineBuffering())
+			}
+			sl := NewStringLog(100, opts...)
+			sl.Write([]byte(tt.input))
+			assert.Equal(t, tt.want, sl.Buffer.Slice())
+		})
+	}
+}
+
+func Test_string_log_lines(t *testing.T) {
+	// given a log with several entries
+	sl := NewStringLog(100)
+	sl.Write([]byte("line1\nline2\nline3\n"))
+
+	// then the lines are joined in order
+	assert.Equal(t, strings.Join(sl.Buffer.Slice(), "\n"), sl.Lines())
+	assert.Equal(t, "line1\nline2\nline3", sl.Lines())
+	assert.Equal(t, "line1, line2, line3", sl.LinesDelimited(", "))
+}
+
+func Test_string_log_lines_when_empty(t *testing.T) {
+	sl := NewStringLog(100)
+	assert.Equal(t, "", sl.Lines())
+}
+
+func Test_string_log_timestamps(t *testing.T) {
+	// given a log with timestamps and a fake clock
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC))
+	sl := NewStringLog(100, WithTimestamps(time.RFC3339), WithStringClock(clock))
+
+	// when lines are written at different times
+	sl.Write([]byte("first\n"))
+	clock.Advance(time.Minute)
+	sl.Write([]byte("second\nthird\n"))
+
+	// then each entry is prefixed with the time of its write
+	assert.Equal(t, []string{
+		"2023-06-01T12:30:00Z first",
+		"2023-06-01T12:31:00Z second",
+		"2023-06-01T12:31:00Z third",
+	}, sl.Buffer.Slice())
+}
+
+func Test_string_log_timestamps_with_line_buffering(t *testing.T) {
+	// given a buffered log with timestamps and a fake clock
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC))
+	sl := NewStringLog(100, WithTimestamps("15:04:05.000"), WithLineBuffering(), WithStringClock(clock))
+
+	// when a line is completed by a later write
+	sl.Write([]byte("Hel"))
+	clock.Advance(1500 * time.Millisecond)
+	sl.Write([]byte("lo\npartial"))
+	clock.Advance(time.Second)
+	sl.Flush()
+
+	// then the entry uses the time of the write that completed it
+	assert.Equal(t, []string{
+		"12:30:01.500 Hello",
+		"12:30:02.500 partial",
+	}, sl.Buffer.Slice())
+}
+
+func Test_string_log_timestamps_off_by_default(t *testing.T) {
+	sl := NewStringLog(100)
+	sl.Write([]byte("Test message\n"))
+	assert.Equal(t, "Test message", sl.Buffer.Slice()[0])
+}
+
+func Test_string_log_max_line_bytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"shorter than limit", "abc", "abc"},
+		{"exactly at limit", "abcdef", "abcdef"},
+		{"over limit", "abcdefghij", "abcdef…[truncated 4 bytes]"},
+		{"multi-byte at cut point", "abcdeé", "abcde…[truncated 2 bytes]"},
+		{"multi-byte ending at limit", "abcdé", "abcdé"},
+		{"cut inside three byte rune", "x日本語", "x日…[truncated 6 bytes]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewStringLog(100, WithMaxLineBytes(6))
+			sl.Write([]byte(tt.input + "\n"))
+			assert.Equal(t, tt.want, sl.Buffer.Slice()[0])
+		})
+	}
+}
+
+func Test_string_log_max_line_bytes_applies_per_line(t *testing.T) {
+	// given a log with a byte limit
+	sl := NewStringLog(100, WithMaxLineBytes(4096))
+
+	// when a huge line is written between short ones
+	huge := strings.Repeat("x", 2*1024*1024)
+	sl.Write([]byte("before\n" + huge + "\nafter\n"))
+
+	// then only the huge line is truncated
+	slice := sl.Buffer.Slice()
+	assert.Equal(t, "before", slice[0])
+	assert.Equal(t, strings.Repeat("x", 4096)+"…[truncated 2093056 bytes]", slice[1])
+	assert.Equal(t, "after", slice[2])
+}
+
+func Test_string_log_default_trim_leading_newline(t *testing.T) {
+	// an unbuffered write is trimmed as a whole
+	sl := NewStringLog(100)
+	sl.Write([]byte("\nline\n"))
+	assert.Equal(t, []string{"line"}, sl.Buffer.Slice())
+
+	// while a buffered newline always terminates a line
+	sl = NewStringLog(100, WithLineBuffering())
+	sl.Write([]byte("\nline\n"))
+	assert.Equal(t, []string{"", "line"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_trim_modes(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   StringLogOption
+		input string
+		want  []string
+	}{
+		{"default trailing spaces", nil, "line  \n", []string{"line  "}},
+		{"default lone cr", nil, "line\r", []string{"line"}},
+		{"default crlf", nil, "a\r\nb\r\n", []string{"a", "b"}},
+		{"right only leading newline", WithTrimRightOnly(), "\nline\n", []string{"", "line"}},
+		{"right only trailing spaces", WithTrimRightOnly(), "  line  \n", []string{"  line  "}},
+		{"right only lone cr", WithTrimRightOnly(), "line\r", []string{"line"}},
+		{"right only crlf", WithTrimRightOnly(), "a\r\nb\r\n", []string{"a", "b"}},
+		{"right only trailing blank line", WithTrimRightOnly(), "a\n\n", []string{"a", ""}},
+		{"raw leading newline", WithRawLines(), "\nline\n", []string{"", "line"}},
+		{"raw trailing spaces", WithRawLines(), "  line  \n", []string{"  line  "}},
+		{"raw lone cr", WithRawLines(), "line\r", []string{"line\r"}},
+		{"raw crlf", WithRawLines(), "a\r\nb\r\n", []string{"a\r", "b\r"}},
+	}
+
+	for _, tt := range tests {
+		for _, buffered := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s buffered=%v", tt.name, buffered), func(t *testing.T) {
+				var opts []StringLogOption
+				if tt.opt != nil {
+					opts = append(opts, tt.opt)
+				}
+				if buffered {
+					opts = append(opts, WithLineBuffering())
+				}
+				sl := NewStringLog(100, opts...)
+				sl.Write([]byte(tt.input))
+				sl.Flush()
+				assert.Equal(t, tt.want, sl.Buffer.Slice())
+			})
+		}
+	}
+}
+
+func Test_string_log_write_string_matches_write(t *testing.T) {
+	inputs := []string{
+		"Test message",
+		"Test message\r\n",
+		"\nline1\nline2\n\nline3\n",
+		"partial",
+		" rest\n",
+	}
+
+	configs := map[string][]StringLogOption{
+		"default":   nil,
+		"buffered":  {WithLineBuffering()},
+		"raw":       {WithRawLines()},
+		"truncated": {WithMaxLineLength(4)},
+	}
+
+	for name, opts := range configs {
+		t.Run(name, func(t *testing.T) {
+			// given two logs with the same options
+			bytesLog := NewStringLog(100, opts...)
+			stringLog := NewStringLog(100, opts...)
+
+			// when the same input is written with Write and WriteString
+			for _, input := range inputs {
+				n1, err1 := bytesLog.Write([]byte(input))
+				n2, err2 := stringLog.WriteString(input)
+				assert.Equal(t, n1, n2)
+				assert.Equal(t, err1, err2)
+			}
+			bytesLog.Flush()
+			stringLog.Flush()
+
+			// then the stored entries are identical
+			assert.Equal(t, bytesLog.Buffer.Slice(), stringLog.Buffer.Slice())
+		})
+	}
+}
+
+func Test_tee_string_log_write_string_forwards(t *testing.T) {
+	var out strings.Builder
+	sl := NewTeeStringLog(100, &out)
+
+	n, err := sl.WriteString("Test message\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 13, n)
+	assert.Equal(t, "Test message\n", out.String())
+	assert.Equal(t, []string{"Test message"}, sl.Buffer.Slice())
+}
+
+var benchmarkLine = strings.Repeat("x", 79) + "\n"
+
+func Benchmark_string_log_write(b *testing.B) {
+	sl := NewStringLog(1000)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		sl.Write([]byte(benchmarkLine))
+	}
+}
+
+func Benchmark_string_log_write_string(b *testing.B) {
+	sl := NewStringLog(1000)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		sl.WriteString(benchmarkLine)
+	}
+}
+
+func Test_string_log_level_filter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		kept  bool
+	}{
+		{"debug", "DEBUG cache miss", false},
+		{"info", "INFO request handled", false},
+		{"warn", "WARN slow response", true},
+		{"warning", "WARNING slow response", true},
+		{"error", "ERROR connection refused", true},
+		{"lower case", "error connection refused", true},
+		{"mixed case", "Warn slow response", true},
+		{"token only", "ERROR", true},
+		{"missing level", "connection refused", false},
+		{"token not first word", "connection ERROR", false},
+		{"token prefix", "ERRORS ignored", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewStringLog(100, WithLevelFilter(LevelWarn))
+			sl.Write([]byte(tt.input + "\n"))
+			if tt.kept {
+				assert.Equal(t, []string{tt.input}, sl.Buffer.Slice())
+			} else {
+				assert.Zero(t, sl.Buffer.Len())
+			}
+		})
+	}
+}
+
+func Test_string_log_level_filter_default_level(t *testing.T) {
+	// given a log treating unrecognized lines as errors
+	sl := NewStringLog(100, WithLevelFilter(LevelWarn), WithDefaultLevel(LevelError))
+
+	// when a line without a level is written
+	sl.Write([]byte("panic: runtime error\n"))
+
+	// then it is kept
+	assert.Equal(t, []string{"panic: runtime error"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_level_filter_custom_tokens(t *testing.T) {
+	sl := NewStringLog(100,
+		WithLevelFilter(LevelWarn),
+		WithLevelTokens(map[string]Level{"trace": LevelDebug, "fatal": LevelError}))
+
+	sl.Write([]byte("TRACE entering\nFATAL exiting\nERROR unknown token\n"))
+
+	assert.Equal(t, []string{"FATAL exiting"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_level_filter_threshold_change(t *testing.T) {
+	// given a log keeping errors only
+	sl := NewStringLog(100, WithLevelFilter(LevelError))
+	sl.Write([]byte("WARN first\nERROR second\n"))
+
+	// when the threshold is lowered at runtime
+	sl.SetMinLevel(LevelDebug)
+	sl.Write([]byte("DEBUG third\n"))
+
+	// then later lines use the new threshold
+	assert.Equal(t, []string{"ERROR second", "DEBUG third"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_level_filter_after_strip_ansi(t *testing.T) {
+	sl := NewStringLog(100, WithStripANSI(), WithLevelFilter(LevelWarn))
+	sl.Write([]byte("\x1b[31mERROR\x1b[0m failed\n\x1b[36mINFO\x1b[0m ok\n"))
+	assert.Equal(t, []string{"ERROR failed"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_level_entries(t *testing.T) {
+	// given a log exposing structured level entries
+	sl := NewStringLog(100, WithLevelEntries(), WithLevelFilter(LevelInfo))
+
+	// when lines are written
+	sl.Write([]byte("DEBUG dropped\ninfo started\nWARN slow\nno level\n"))
+
+	// then the parsed level is stored alongside each kept line
+	assert.Equal(t, []LevelEntry[string]{
+		{Level: LevelInfo, Value: "info started"},
+		{Level: LevelWarn, Value: "WARN slow"},
+		{Level: LevelInfo, Value: "no level"},
+	}, sl.Levels.Slice())
+	assert.Equal(t, []string{"info started", "WARN slow", "no level"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_levels_disabled_by_default(t *testing.T) {
+	sl := NewStringLog(100)
+	sl.Write([]byte("DEBUG kept\n"))
+	assert.Nil(t, sl.Levels)
+	assert.Equal(t, []string{"DEBUG kept"}, sl.Buffer.Slice())
+}
+
+// errorReader returns data followed by err.
+type errorReader struct {
+	data []byte
+	err  error
+}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func Test_string_log_read_from_keeps_last_lines(t *testing.T) {
+	// given a log smaller than the input
+	sl := NewStringLog(3)
+
+	// when a large input is read
+	var input strings.Builder
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&input, "line %d\r\n", i)
+	}
+	n, err := sl.ReadFrom(strings.NewReader(input.String()))
+
+	// then only the last lines are kept
+	assert.NoError(t, err)
+	assert.Equal(t, int64(input.Len()), n)
+	assert.Equal(t, []string{"line 9997", "line 9998", "line 9999"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_final_line_without_newline(t *testing.T) {
+	sl := NewStringLog(10)
+
+	_, err := sl.ReadFrom(strings.NewReader("first\nsecond"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_long_line(t *testing.T) {
+	// given a line longer than bufio.Scanner's default limit
+	long := strings.Repeat("x", 200*1024)
+	sl := NewStringLog(10)
+
+	// when it is read
+	_, err := sl.ReadFrom(strings.NewReader("short\n" + long + "\nend\n"))
+
+	// then it is stored intact
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"short", long, "end"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_small_reads(t *testing.T) {
+	// given a long line delivered a byte at a time
+	long := strings.Repeat("y", 64*1024)
+	sl := NewStringLog(10)
+
+	// when it is read
+	_, err := sl.ReadFrom(iotest.OneByteReader(strings.NewReader("a\n" + long + "\nb")))
+
+	// then each line is assembled intact
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", long, "b"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_continues_pending_line(t *testing.T) {
+	// given a buffered log holding a partial line
+	sl := NewStringLog(10, WithLineBuffering())
+	sl.Write([]byte("first\nsec"))
+
+	// when the rest of the line is read
+	_, err := sl.ReadFrom(strings.NewReader("ond\nthird"))
+
+	// then the partial line is completed
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_blank_input(t *testing.T) {
+	sl := NewStringLog(10)
+
+	_, err := sl.ReadFrom(strings.NewReader("\r\n\n\n"))
+
+	assert.NoError(t, err)
+	assert.Empty(t, sl.Buffer.Slice())
+}
+
+func Test_string_log_read_from_matches_write(t *testing.T) {
+	inputs := []string{
+		"\r\n\nfirst\n\nsecond\r\n\n\n",
+		"a\r\n\r\nb",
+		"a\n\r\n",
+	}
+	modes := map[string][]StringLogOption{
+		"default":    nil,
+		"right only": {WithTrimRightOnly()},
+		"raw":        {WithRawLines()},
+	}
+
+	for name, opts := range modes {
+		for _, input := range inputs {
+			// given the same input written and read
+			written := NewStringLog(10, opts...)
+			written.Write([]byte(input))
+			read := NewStringLog(10, opts...)
+			_, err := read.ReadFrom(iotest.HalfReader(strings.NewReader(input)))
+
+			// then the same entries are stored
+			assert.NoError(t, err)
+			assert.Equal(t, written.Buffer.Slice(), read.Buffer.Slice(), "%s %q", name, input)
+		}
+	}
+}
+
+func Test_string_log_read_from_reader_error(t *testing.T) {
+	// given a reader that fails midway
+	readErr := errors.New("connection reset")
+	r := &errorReader{data: []byte("first\nsecond\nthi"), err: readErr}
+	sl := NewStringLog(10)
+
+	// when it is read
+	n, err := sl.ReadFrom(r)
+
+	// then the error is returned and the data read is kept
+	assert.ErrorIs(t, err, readErr)
+	assert.Equal(t, int64(16), n)
+	assert.Equal(t, []string{"first", "second", "thi"}, sl.Buffer.Slice())
+}
+
+func Test_tee_string_log_read_from_forwards(t *testing.T) {
+	var out strings.Builder
+	sl := NewTeeStringLog(10, &out)
+
+	input := "first\r\nsecond\n"
+	io.Copy(sl, strings.NewReader(input))
+
+	assert.Equal(t, input, out.String())
+	assert.Equal(t, []string{"first", "second"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_text(t *testing.T) {
+	// given a log with several entries
+	sl := NewStringLog(100)
+	sl.Write([]byte("line1\nline2\n\nline4\n"))
+
+	// then text joins all entries
+	assert.Equal(t, "line1\nline2\n\nline4", sl.Text())
+	assert.Equal(t, strings.Join(sl.Buffer.Slice(), "\n"), sl.Text())
+
+	// and last n text joins the most recent entries
+	assert.Equal(t, "\nline4", sl.LastNText(2))
+	assert.Equal(t, "line4", sl.LastNText(1))
+	assert.Equal(t, "", sl.LastNText(0))
+	assert.Equal(t, sl.Text(), sl.LastNText(50))
+}
+
+func Test_string_log_text_when_empty(t *testing.T) {
+	sl := NewStringLog(100, WithTrailingNewline())
+	assert.Equal(t, "", sl.Text())
+	assert.Equal(t, "", sl.LastNText(5))
+}
+
+func Test_string_log_text_with_trailing_newline(t *testing.T) {
+	sl := NewStringLog(100, WithTrailingNewline())
+	sl.Write([]byte("line1\nline2\nline3\n"))
+
+	assert.Equal(t, "line1\nline2\nline3\n", sl.Text())
+	assert.Equal(t, "line3\n", sl.LastNText(1))
+	assert.Equal(t, "line1\nline2\nline3\n", sl.LastNText(10))
+}
+
+func newBenchmarkStringLog() *StringLog {
+	sl := NewStringLog(1000)
+	for i := 0; i < 1000; i++ {
+		sl.WriteString(benchmarkLine)
+	}
+	return sl
+}
+
+func Benchmark_string_log_text(b *testing.B) {
+	sl := newBenchmarkStringLog()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = sl.Text()
+	}
+}
+
+func Benchmark_string_log_slice_join(b *testing.B) {
+	sl := newBenchmarkStringLog()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = strings.Join(sl.Buffer.Slice(), "\n")
+	}
+}
+
+func Test_string_log_expvar(t *testing.T) {
+	// given a published log
+	sl := NewStringLog(10)
+	expvar.Publish("Test_string_log_expvar", sl)
+
+	// when entries are written
+	sl.Write([]byte("first\n\"quoted\"\n"))
+
+	// then the published value is a json array of the entries
+	var got []string
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("Test_string_log_expvar").String()), &got))
+	assert.Equal(t, sl.Buffer.Slice(), got)
+	assert.Equal(t, `["first","\"quoted\""]`, sl.String())
+}
+
+func Test_string_log_expvar_when_empty(t *testing.T) {
+	assert.Equal(t, "[]", NewStringLog(10).String())
+}
+
+func Test_string_log_flush_with_nothing_pending(t *testing.T) {
+	sl := NewStringLog(10, WithLineBuffering())
+	sl.Write([]byte("complete\n"))
+
+	assert.NoError(t, sl.Flush())
+	assert.Equal(t, []string{"complete"}, sl.Buffer.Slice())
+}
+
+func Test_string_log_close_flushes_partial_line(t *testing.T) {
+	// given a buffered log with a partial line
+	sl := NewStringLog(10, WithLineBuffering())
+	sl.Write([]byte("partial"))
+
+	// when it is closed
+	assert.NoError(t, sl.Close())
+
+	// then the partial line is stored
+	assert.Equal(t, []string{"partial"}, sl.Buffer.Slice())
+
+	// and closing again has no effect
+	assert.NoError(t, sl.Close())
+	assert.Equal(t, 1, sl.Buffer.Len())
+}
+
+func Test_string_log_write_after_close(t *testing.T) {
+	// given a closed tee log
+	var out strings.Builder
+	sl := NewTeeStringLog(10, &out)
+	sl.Write([]byte("before\n"))
+	sl.Close()
+
+	// when it is written to
+	n, err := sl.Write([]byte("after\n"))
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Zero(t, n)
+
+	n, err = sl.WriteString("after\n")
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Zero(t, n)
+
+	_, err = sl.ReadFrom(strings.NewReader("after\n"))
+	assert.ErrorIs(t, err, ErrClosed)
+
+	// then nothing is stored or forwarded
+	assert.Equal(t, []string{"before"}, sl.Buffer.Slice())
+	assert.Equal(t, "before\n", out.String())
+}
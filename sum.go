@@ -0,0 +1,51 @@
+package memlog
+
+// Number is a constraint that permits any integer
+// or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns the sum of the entries in log.  The sum is
+// computed in T, so for integer types it wraps around on
+// overflow.
+func Sum[T Number](log *MemLog[T]) T {
+	log.rlock()
+	defer log.runlock()
+
+	var sum T
+	log.forEachN(allElements, func(item T) {
+		sum += item
+	})
+
+	return sum
+}
+
+// Average returns the mean of the entries in log, or 0 if
+// the log is empty.  The mean is computed using float64 so
+// it does not overflow for integer types.
+func Average[T Number](log *MemLog[T]) float64 {
+	mean, _ := Mean(log)
+	return mean
+}
+
+// Mean is like Average but returns false if the log is
+// empty.  If any entry is NaN the mean is NaN.
+func Mean[T Number](log *MemLog[T]) (mean float64, ok bool) {
+	log.rlock()
+	defer log.runlock()
+
+	n := log.entries.len()
+	if n == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	log.forEachN(allElements, func(item T) {
+		sum += float64(item)
+	})
+
+	return sum / float64(n), true
+}
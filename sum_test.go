@@ -0,0 +1,127 @@
+package memlog
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sum_and_average(t *testing.T) {
+	// given a log of integers
+	log := NewMemLog[int](5)
+	for i := 1; i <= 6; i++ {
+		log.Append(i)
+	}
+
+	// then the retained entries are summed and averaged
+	assert.Equal(t, 20, Sum(log))
+	assert.Equal(t, 4.0, Average(log))
+}
+
+func Test_sum_and_average_floats(t *testing.T) {
+	log := NewMemLog[float64](5)
+	log.Append(0.5)
+	log.Append(1.5)
+	log.Append(4)
+
+	assert.Equal(t, 6.0, Sum(log))
+	assert.Equal(t, 2.0, Average(log))
+}
+
+func Test_sum_and_average_empty(t *testing.T) {
+	log := NewMemLog[int](5)
+
+	assert.Zero(t, Sum(log))
+	assert.Zero(t, Average(log))
+}
+
+func Test_mean(t *testing.T) {
+	// given logs of integers and floats
+	ints := NewMemLog[int64](3)
+	for _, v := range []int64{100, 1, 2, 6} {
+		ints.Append(v)
+	}
+	floats := NewMemLog[float64](3)
+	floats.Append(0.5)
+	floats.Append(2.5)
+
+	// then the retained entries are averaged
+	mean, ok := Mean(ints)
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, mean)
+
+	mean, ok = Mean(floats)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, mean)
+}
+
+func Test_mean_empty(t *testing.T) {
+	mean, ok := Mean(NewMemLog[float64](3))
+
+	assert.False(t, ok)
+	assert.Zero(t, mean)
+}
+
+func Test_mean_nan(t *testing.T) {
+	// given a log containing NaN
+	log := NewMemLog[float64](3)
+	log.Append(1)
+	log.Append(math.NaN())
+	log.Append(2)
+
+	// then the mean is NaN
+	mean, ok := Mean(log)
+	assert.True(t, ok)
+	assert.True(t, math.IsNaN(mean))
+
+	// until the NaN is evicted
+	log.Append(3)
+	log.Append(4)
+	mean, _ = Mean(log)
+	assert.Equal(t, 3.0, mean)
+}
+
+func Test_sum_and_average_overflow(t *testing.T) {
+	// given entries whose sum overflows their type
+	log := NewMemLog[int8](5)
+	log.Append(100)
+	log.Append(100)
+
+	// then the sum wraps but the average does not
+	assert.Equal(t, int8(-56), Sum(log))
+	assert.Equal(t, 100.0, Average(log))
+
+	big := NewMemLog[int64](5)
+	big.Append(math.MaxInt64)
+	big.Append(math.MaxInt64)
+	assert.InDelta(t, float64(math.MaxInt64), Average(big), 1)
+}
+
+func Benchmark_sum(b *testing.B) {
+	log := NewMemLog[int](5000)
+	for i := 0; i < 5000; i++ {
+		log.Append(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Sum(log)
+	}
+}
+
+func Benchmark_sum_slice(b *testing.B) {
+	log := NewMemLog[int](5000)
+	for i := 0; i < 5000; i++ {
+		log.Append(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, v := range log.Slice() {
+			sum += v
+		}
+		_ = sum
+	}
+}
@@ -0,0 +1,51 @@
+package memlog
+
+import (
+	"io"
+	"text/template"
+)
+
+// TemplateData is the data passed to a template by
+// ExecuteTemplate.
+type TemplateData[T any] struct {
+	Entries []T
+	Len     int
+	Cap     int
+	Stats   Stats
+}
+
+// ExecuteTemplate executes tmpl once, writing to w, with a
+// TemplateData holding a snapshot of the entries, ordered from
+// oldest to newest, and the log's size and counters.
+//
+// Templates write to w as they execute, so if tmpl returns an
+// error the output written before the error has already been
+// written to w.
+func (m *MemLog[T]) ExecuteTemplate(w io.Writer, tmpl *template.Template) error {
+	m.rlock()
+	data := TemplateData[T]{
+		Entries: m.toSlice(m.entries.len()),
+		Len:     m.entries.len(),
+		Cap:     m.Cap(),
+		Stats:   m.stats,
+	}
+	data.Stats.Bytes = m.bytes
+	data.Stats.OutOfOrder = m.outOfOrder
+	m.runlock()
+
+	return tmpl.Execute(w, data)
+}
+
+// ExecuteTemplatePerEntry executes tmpl for each entry in the
+// log, ordered from oldest to newest, writing to w.  The entry
+// is passed to the template as its data.  It stops at the
+// first error, after the output of the preceding entries, and
+// any output of the failing one, has been written to w.
+func (m *MemLog[T]) ExecuteTemplatePerEntry(w io.Writer, tmpl *template.Template) error {
+	for _, item := range m.Slice() {
+		if err := tmpl.Execute(w, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
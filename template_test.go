@@ -0,0 +1,66 @@
+package memlog
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type templateEntry struct {
+	Level   string
+	Message string
+}
+
+func newTemplateLog() *MemLog[templateEntry] {
+	log := NewMemLog[templateEntry](3)
+	log.Append(templateEntry{"INFO", "started"})
+	log.Append(templateEntry{"WARN", "slow"})
+	log.Append(templateEntry{"ERROR", "failed"})
+	log.Append(templateEntry{"INFO", "recovered"})
+	return log
+}
+
+func Test_memlog_execute_template(t *testing.T) {
+	// given a template rendering the whole log
+	tmpl := template.Must(template.New("log").Parse(
+		"{{.Len}}/{{.Cap}} entries, {{.Stats.TotalEvictions}} evicted\n" +
+			"{{range .Entries}}[{{.Level}}] {{.Message}}\n{{end}}"))
+
+	// when it is executed
+	var sb strings.Builder
+	err := newTemplateLog().ExecuteTemplate(&sb, tmpl)
+
+	// then the entries and counters are rendered
+	assert.NoError(t, err)
+	assert.Equal(t, "3/3 entries, 1 evicted\n[WARN] slow\n[ERROR] failed\n[INFO] recovered\n", sb.String())
+}
+
+func Test_memlog_execute_template_per_entry(t *testing.T) {
+	tmpl := template.Must(template.New("entry").Parse("{{.Level}}: {{.Message}}\n"))
+
+	var sb strings.Builder
+	err := newTemplateLog().ExecuteTemplatePerEntry(&sb, tmpl)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WARN: slow\nERROR: failed\nINFO: recovered\n", sb.String())
+}
+
+func Test_memlog_execute_template_missing_field(t *testing.T) {
+	// given templates referencing a field entries do not have
+	whole := template.Must(template.New("log").Parse("{{.Len}} entries\n{{range .Entries}}{{.Missing}}{{end}}"))
+	each := template.Must(template.New("entry").Parse("{{.Level}} {{.Missing}}\n"))
+	log := newTemplateLog()
+
+	// when they are executed
+	var sbWhole, sbEach strings.Builder
+	errWhole := log.ExecuteTemplate(&sbWhole, whole)
+	errEach := log.ExecuteTemplatePerEntry(&sbEach, each)
+
+	// then an error is returned after the partial output is written
+	assert.ErrorContains(t, errWhole, "can't evaluate field Missing")
+	assert.Equal(t, "3 entries\n", sbWhole.String())
+	assert.ErrorContains(t, errEach, "can't evaluate field Missing")
+	assert.Equal(t, "WARN ", sbEach.String())
+}
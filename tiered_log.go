@@ -0,0 +1,281 @@
+package memlog
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// coldBlockSize is the largest number of entries
+// compressed together into a single cold block.
+const coldBlockSize = 256
+
+// errCorruptBlock is returned when a cold
+// block cannot be decoded.
+var errCorruptBlock = errors.New("memlog: corrupt cold block")
+
+// coldBlock is a compressed run of consecutive entries.
+type coldBlock struct {
+	data  []byte
+	lines int
+	raw   int
+}
+
+// TieredStats describes the contents of a TieredLog.
+type TieredStats struct {
+	// HotLen is the number of uncompressed entries.
+	HotLen int
+
+	// ColdLen is the number of entries in the cold
+	// tier, including any not yet compressed.
+	ColdLen int
+
+	// CompressedBytes is the size of the compressed
+	// blocks in the cold tier.
+	CompressedBytes int
+
+	// UncompressedBytes is the size of the entries held
+	// in the compressed blocks before compression.
+	UncompressedBytes int
+
+	// CorruptBlocks is the number of times a cold block
+	// could not be decompressed.  The entries it held are
+	// left out of the slices returned by Slice and SliceN.
+	CorruptBlocks int
+}
+
+// TieredLog is a log of strings that keeps the newest entries
+// in a MemLog and compresses older entries in memory.  Entries
+// evicted from the hot tier are batched into compressed blocks
+// held in a bounded cold tier.  Reads that reach back into the
+// cold tier decompress the blocks they need.
+//
+// This allows a long history to be kept cheaply when only the
+// most recent entries are read frequently.
+//
+// TieredLog is thread-safe
+type TieredLog struct {
+	hot       *MemLog[string]
+	hotSize   int
+	coldSize  int
+	blockSize int
+	pending   []string
+	blocks    []coldBlock
+	skip      int
+	coldLen   int
+	corrupt   int
+	locker    sync.Mutex
+}
+
+// NewTieredLog returns a new TieredLog that keeps hotSize
+// entries uncompressed and up to coldSize older entries
+// compressed.  Entries are compressed in blocks of up to 256
+// entries, or coldSize if it is smaller, and a block is freed
+// once all of its entries have aged out, so the cold tier may
+// hold the data of up to one block beyond coldSize.
+//
+// NewTieredLog panics if hotSize is not positive, as every
+// entry passes through the hot tier.
+func NewTieredLog(hotSize, coldSize int) *TieredLog {
+	if hotSize <= 0 {
+		panic("memlog: NewTieredLog requires a positive hotSize")
+	}
+
+	return &TieredLog{
+		hot:       NewMemLog[string](hotSize),
+		hotSize:   hotSize,
+		coldSize:  coldSize,
+		blockSize: max(min(coldBlockSize, coldSize), 1),
+	}
+}
+
+// Append will add line to the log, moving the
+// oldest hot entry to the cold tier if necessary.
+func (t *TieredLog) Append(line string) {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	if t.hot.Len() >= t.hotSize {
+		if oldest, ok := t.hot.Pop(); ok {
+			t.appendCold(oldest)
+		}
+	}
+	t.hot.Append(line)
+}
+
+// appendCold adds line to the cold tier.  The
+// caller must hold the lock.
+func (t *TieredLog) appendCold(line string) {
+	if t.coldSize <= 0 {
+		return
+	}
+
+	t.pending = append(t.pending, line)
+	t.coldLen++
+
+	if len(t.pending) >= t.blockSize {
+		t.blocks = append(t.blocks, compressBlock(t.pending))
+		t.pending = t.pending[:0]
+	}
+
+	// age out the oldest entries one at a time, skipping
+	// them in the first block until it can be freed
+	for t.coldLen > t.coldSize {
+		excess := t.coldLen - t.coldSize
+		if len(t.blocks) == 0 {
+			t.pending = t.pending[excess:]
+			t.coldLen -= excess
+			return
+		}
+
+		if remaining := t.blocks[0].lines - t.skip; remaining <= excess {
+			t.blocks = t.blocks[1:]
+			t.skip = 0
+			t.coldLen -= remaining
+			continue
+		}
+		t.skip += excess
+		t.coldLen -= excess
+	}
+}
+
+// Len returns the number of entries in the log.
+func (t *TieredLog) Len() int {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+	return t.hot.Len() + t.coldLen
+}
+
+// Stats returns a description of the log's tiers.
+func (t *TieredLog) Stats() TieredStats {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	stats := TieredStats{
+		HotLen:        t.hot.Len(),
+		ColdLen:       t.coldLen,
+		CorruptBlocks: t.corrupt,
+	}
+	for _, block := range t.blocks {
+		stats.CompressedBytes += len(block.data)
+		stats.UncompressedBytes += block.raw
+	}
+
+	return stats
+}
+
+// Clear will clear the current contents of the log.
+func (t *TieredLog) Clear() {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	t.hot.Clear()
+	t.pending = nil
+	t.blocks = nil
+	t.skip = 0
+	t.coldLen = 0
+}
+
+// Slice returns the contents of the log as a slice.
+// The slice is ordered from oldest item to the newest
+func (t *TieredLog) Slice() []string {
+	return t.SliceN(allElements)
+}
+
+// SliceN returns the last 'N' items from the log, only
+// decompressing the cold blocks needed to satisfy n.
+// The slice is ordered from oldest item to the newest
+func (t *TieredLog) SliceN(n int) []string {
+	t.locker.Lock()
+	defer t.locker.Unlock()
+
+	total := t.hot.Len() + t.coldLen
+	if n <= allElements || n > total {
+		n = total
+	}
+
+	hot := t.hot.SliceN(n)
+	need := n - len(hot)
+	if need == 0 {
+		return hot
+	}
+
+	// gather the newest cold blocks covering need entries
+	cold := t.pending
+	if need > len(cold) {
+		first := len(t.blocks)
+		covered := len(t.pending)
+		for first > 0 && covered < need {
+			first--
+			covered += t.blocks[first].lines
+			if first == 0 {
+				covered -= t.skip
+			}
+		}
+
+		var lines []string
+		for i := first; i < len(t.blocks); i++ {
+			block, err := decompressBlock(t.blocks[i])
+			if err != nil {
+				t.corrupt++
+				continue
+			}
+			if i == 0 {
+				block = block[t.skip:]
+			}
+			lines = append(lines, block...)
+		}
+		cold = append(lines, t.pending...)
+	}
+	need = min(need, len(cold))
+
+	slice := make([]string, 0, need+len(hot))
+	slice = append(slice, cold[len(cold)-need:]...)
+	return append(slice, hot...)
+}
+
+// compressBlock compresses lines into a cold block.  Each
+// line is stored with a length prefix so lines may contain
+// any character.
+func compressBlock(lines []string) coldBlock {
+	var raw []byte
+	for _, line := range lines {
+		raw = binary.AppendUvarint(raw, uint64(len(line)))
+		raw = append(raw, line...)
+	}
+
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	w.Write(raw)
+	w.Close()
+
+	return coldBlock{data: buf.Bytes(), lines: len(lines), raw: len(raw)}
+}
+
+// decompressBlock returns the lines held in block.
+func decompressBlock(block coldBlock) ([]string, error) {
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(block.data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCorruptBlock, err)
+	}
+
+	lines := make([]string, 0, block.lines)
+	for len(raw) > 0 {
+		n, size := binary.Uvarint(raw)
+		if size <= 0 || n > uint64(len(raw)-size) {
+			return nil, errCorruptBlock
+		}
+		raw = raw[size:]
+		lines = append(lines, string(raw[:n]))
+		raw = raw[n:]
+	}
+
+	if len(lines) != block.lines {
+		return nil, errCorruptBlock
+	}
+	return lines, nil
+}
@@ -0,0 +1,140 @@
+package memlog
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tiered_log_round_trip(t *testing.T) {
+	// given a tiered log with a small hot tier
+	log := NewTieredLog(10, 1000)
+	var want []string
+	for i := 0; i < 700; i++ {
+		line := fmt.Sprintf("request %d completed in %dms\nwith detail", i, i%17)
+		want = append(want, line)
+		log.Append(line)
+	}
+
+	// then entries are returned unchanged across the hot/cold boundary
+	assert.Equal(t, 700, log.Len())
+	assert.Equal(t, want, log.Slice())
+	assert.Equal(t, want[695:], log.SliceN(5))
+	assert.Equal(t, want[680:], log.SliceN(20))
+	assert.Equal(t, want[100:], log.SliceN(600))
+
+	stats := log.Stats()
+	assert.Equal(t, 10, stats.HotLen)
+	assert.Equal(t, 690, stats.ColdLen)
+	assert.Less(t, stats.CompressedBytes*3, stats.UncompressedBytes)
+}
+
+func Test_tiered_log_cold_bound(t *testing.T) {
+	// given a tiered log with a bounded cold tier
+	log := NewTieredLog(10, 600)
+
+	// when many more entries are appended than it holds
+	for i := 0; i < 10000; i++ {
+		log.Append(fmt.Sprintf("line %d", i))
+	}
+
+	// then the cold tier holds exactly its bound, and the data
+	// of at most one block beyond it
+	stats := log.Stats()
+	assert.Equal(t, 600, stats.ColdLen)
+	assert.Equal(t, 610, log.Len())
+	assert.LessOrEqual(t, stats.UncompressedBytes, (600+coldBlockSize)*len("line 10000"))
+
+	slice := log.Slice()
+	assert.Len(t, slice, log.Len())
+	assert.Equal(t, "line 9999", slice[len(slice)-1])
+	for i := 1; i < len(slice); i++ {
+		assert.Equal(t, fmt.Sprintf("line %d", 10000-len(slice)+i), slice[i])
+	}
+}
+
+func Test_tiered_log_without_cold_tier(t *testing.T) {
+	log := NewTieredLog(2, 0)
+	log.Append("a")
+	log.Append("b")
+	log.Append("c")
+
+	assert.Equal(t, []string{"b", "c"}, log.Slice())
+
+	log.Clear()
+	assert.Zero(t, log.Len())
+}
+
+func Test_tiered_log_small_cold_tier(t *testing.T) {
+	// given a cold tier smaller than the default block size
+	log := NewTieredLog(2, 10)
+
+	// when entries overflow into the cold tier
+	for i := 0; i < 35; i++ {
+		log.Append(fmt.Sprintf("line %d", i))
+	}
+
+	// then cold entries are compressed and age out one at a time
+	stats := log.Stats()
+	assert.Equal(t, 10, stats.ColdLen)
+	assert.Positive(t, stats.CompressedBytes)
+
+	var want []string
+	for i := 23; i < 35; i++ {
+		want = append(want, fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, want, log.Slice())
+	assert.Equal(t, want[1:], log.SliceN(11))
+
+	log.Append("line 35")
+	assert.Equal(t, append(want[1:], "line 35"), log.Slice())
+}
+
+func Test_tiered_log_requires_hot_tier(t *testing.T) {
+	assert.Panics(t, func() { NewTieredLog(0, 10) })
+	assert.Panics(t, func() { NewTieredLog(-1, 10) })
+}
+
+func Test_tiered_log_corrupt_block(t *testing.T) {
+	// given a log with a cold block that has been corrupted
+	log := NewTieredLog(1, 10)
+	for i := 0; i < 25; i++ {
+		log.Append(fmt.Sprintf("line %d", i))
+	}
+	log.blocks[0].data = []byte("not flate")
+
+	// when the log is read
+	var slice []string
+	assert.NotPanics(t, func() { slice = log.Slice() })
+
+	// then the entries of the corrupt block are left out
+	assert.Equal(t, []string{"line 20", "line 21", "line 22", "line 23", "line 24"}, slice)
+	assert.Equal(t, 1, log.Stats().CorruptBlocks)
+}
+
+func Test_tiered_decompress_block_errors(t *testing.T) {
+	block := compressBlock([]string{"a", "b"})
+	lines, err := decompressBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines)
+
+	// a block that is not flate data
+	_, err = decompressBlock(coldBlock{data: []byte("junk"), lines: 1})
+	assert.ErrorIs(t, err, errCorruptBlock)
+
+	// a block with the wrong number of lines
+	block.lines = 3
+	_, err = decompressBlock(block)
+	assert.ErrorIs(t, err, errCorruptBlock)
+
+	// a block whose length prefix runs past its data
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	w.Write([]byte{10, 'a'})
+	w.Close()
+	_, err = decompressBlock(coldBlock{data: buf.Bytes(), lines: 1})
+	assert.ErrorIs(t, err, errCorruptBlock)
+}
@@ -0,0 +1,71 @@
+package memlog
+
+import "time"
+
+// TimedLog is a log of timestamped entries that are
+// discarded once they are older than a maximum age, in
+// addition to being bounded by a number of entries.
+// Expired entries are removed lazily, when the log is read.
+//
+// TimedLog is thread-safe
+type TimedLog[T any] struct {
+	Buffer *MemLog[TimestampedEntry[T]]
+	maxAge time.Duration
+}
+
+// NewTimedLog returns a new TimedLog that holds at most
+// maxEntries entries, none older than maxAge.
+func NewTimedLog[T any](maxAge time.Duration, maxEntries int, opts ...Option[TimestampedEntry[T]]) *TimedLog[T] {
+	return &TimedLog[T]{
+		Buffer: NewTimestampedLog[T](maxEntries, opts...),
+		maxAge: maxAge,
+	}
+}
+
+// Append adds v to the log along with the current time.
+func (l *TimedLog[T]) Append(v T) {
+	AppendTimestamped(l.Buffer, v)
+}
+
+// Len removes expired entries and returns the
+// number of entries that remain.
+func (l *TimedLog[T]) Len() int {
+	l.expire()
+	return l.Buffer.Len()
+}
+
+// Slice removes expired entries and returns those that
+// remain, ordered from oldest to newest.
+func (l *TimedLog[T]) Slice() []TimestampedEntry[T] {
+	l.expire()
+	return l.Buffer.Slice()
+}
+
+// SliceN removes expired entries and returns the newest n
+// of those that remain, ordered from oldest to newest.
+func (l *TimedLog[T]) SliceN(n int) []TimestampedEntry[T] {
+	l.expire()
+	return l.Buffer.SliceN(n)
+}
+
+// expire removes entries older than the maximum age.  Entries
+// are normally in timestamp order, so expired entries are
+// removed from the front of the log until a live one is found;
+// the whole log is only scanned if an entry was appended to
+// the Buffer out of order.
+func (l *TimedLog[T]) expire() {
+	log := l.Buffer
+	log.locker.Lock()
+	defer log.locker.Unlock()
+
+	cutoff := log.now().Add(-l.maxAge)
+	expired := func(entry TimestampedEntry[T]) bool {
+		return entry.Timestamp.Before(cutoff)
+	}
+
+	if log.outOfOrder {
+		log.removeIf(expired)
+		return
+	}
+	log.removeFrontWhile(expired)
+}
@@ -0,0 +1,97 @@
+package memlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func timedValues[T any](entries []TimestampedEntry[T]) []T {
+	var values []T
+	for _, e := range entries {
+		values = append(values, e.Value)
+	}
+	return values
+}
+
+func Test_timed_log_expires_entries(t *testing.T) {
+	// given a timed log with a maximum age of one minute
+	clock := NewFakeClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	log := NewTimedLog(time.Minute, 10, WithClock[TimestampedEntry[string]](clock))
+
+	// when entries are appended over time
+	log.Append("a")
+	clock.Advance(30 * time.Second)
+	log.Append("b")
+	clock.Advance(30 * time.Second)
+	log.Append("c")
+
+	// then an entry exactly the maximum age is kept
+	assert.Equal(t, 3, log.Len())
+
+	// and entries older than the maximum age are removed
+	clock.Advance(time.Second)
+	assert.Equal(t, []string{"b", "c"}, timedValues(log.Slice()))
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, 0, log.Len())
+	assert.Empty(t, log.Slice())
+}
+
+func Test_timed_log_slice_n(t *testing.T) {
+	// given a timed log with some expired entries
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimedLog(time.Minute, 10, WithClock[TimestampedEntry[int]](clock))
+
+	for i := 0; i < 5; i++ {
+		log.Append(i)
+		clock.Advance(20 * time.Second)
+	}
+
+	// when the newest entries are requested
+	slice := log.SliceN(10)
+
+	// then only unexpired entries are returned
+	assert.Equal(t, []int{2, 3, 4}, timedValues(slice))
+	assert.Equal(t, []int{3, 4}, timedValues(log.SliceN(2)))
+}
+
+func Test_timed_log_max_entries(t *testing.T) {
+	log := NewTimedLog[int](time.Hour, 2)
+
+	log.Append(1)
+	log.Append(2)
+	log.Append(3)
+
+	assert.Equal(t, []int{2, 3}, timedValues(log.Slice()))
+}
+
+func Test_timed_log_expires_entries_out_of_order(t *testing.T) {
+	// given a timed log whose buffer holds an entry out of order
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimedLog(time.Minute, 10, WithClock[TimestampedEntry[string]](clock))
+	log.Append("a")
+	log.Buffer.Append(TimestampedEntry[string]{Timestamp: start.Add(-time.Hour), Value: "old"})
+	log.Append("b")
+
+	// when the log is read
+	values := timedValues(log.Slice())
+
+	// then the expired entry is removed even though it is not the oldest
+	assert.Equal(t, []string{"a", "b"}, values)
+}
+
+func Benchmark_timed_log_len_without_expired_entries(b *testing.B) {
+	log := NewTimedLog[int](time.Hour, 100000)
+	for i := 0; i < 100000; i++ {
+		log.Append(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Len()
+	}
+}
@@ -0,0 +1,180 @@
+package memlog
+
+import (
+	"sort"
+	"time"
+)
+
+// TimestampedEntry is a log entry that records
+// the time at which it was added to the log.
+type TimestampedEntry[T any] struct {
+	Timestamp time.Time
+	Value     T
+}
+
+// NewTimestampedLog returns a new MemLog of timestamped
+// entries that will not grow beyond size entries.  The log
+// tracks whether entries are appended in time order so that
+// time queries can use a binary search.
+func NewTimestampedLog[T any](size int, opts ...Option[TimestampedEntry[T]]) *MemLog[TimestampedEntry[T]] {
+	opts = append(opts, func(m *MemLog[TimestampedEntry[T]]) {
+		m.timeOf = func(entry TimestampedEntry[T]) time.Time {
+			return entry.Timestamp
+		}
+	})
+	return NewMemLog(size, opts...)
+}
+
+// AppendTimestamped adds v to log along with the current
+// time.  The time is read while the log is locked, so entries
+// appended concurrently are stored in timestamp order.
+func AppendTimestamped[T any](log *MemLog[TimestampedEntry[T]], v T) {
+	log.appendBuilt(func() TimestampedEntry[T] {
+		return TimestampedEntry[T]{
+			Timestamp: log.now(),
+			Value:     v,
+		}
+	})
+}
+
+// SliceSinceTime returns the entries in log with a timestamp
+// at or after t.  It is equivalent to SliceSince.
+func SliceSinceTime[T any](log *MemLog[TimestampedEntry[T]], t time.Time) []TimestampedEntry[T] {
+	return SliceSince(log, t)
+}
+
+// SliceSince returns the entries in log with a timestamp
+// at or after t.  The slice is ordered from oldest item to
+// the newest.
+func SliceSince[T any](log *MemLog[TimestampedEntry[T]], t time.Time) []TimestampedEntry[T] {
+	return sliceTimes(log, func(ts time.Time) bool {
+		return !ts.Before(t)
+	}, nil)
+}
+
+// SliceBefore returns the entries in log with a timestamp
+// before t.  The slice is ordered from oldest item to
+// the newest.
+func SliceBefore[T any](log *MemLog[TimestampedEntry[T]], t time.Time) []TimestampedEntry[T] {
+	return sliceTimes(log, nil, func(ts time.Time) bool {
+		return ts.Before(t)
+	})
+}
+
+// SliceBetween returns the entries in log with a timestamp at
+// or after start and before end.  The slice is ordered from
+// oldest item to the newest.
+func SliceBetween[T any](log *MemLog[TimestampedEntry[T]], start, end time.Time) []TimestampedEntry[T] {
+	return sliceTimes(log, func(ts time.Time) bool {
+		return !ts.Before(start)
+	}, func(ts time.Time) bool {
+		return ts.Before(end)
+	})
+}
+
+// sliceTimes returns the entries in log whose timestamps
+// satisfy both from and to, either of which may be nil.
+//
+// Entries are normally appended in time order, as they are by
+// AppendTimestamped, which allows the boundaries to be located
+// with a binary search.  Only logs created by NewTimestampedLog
+// track the order of their entries, so other logs are always
+// scanned, as are logs that have had an entry appended out of
+// order.
+func sliceTimes[T any](log *MemLog[TimestampedEntry[T]], from, to func(time.Time) bool) []TimestampedEntry[T] {
+	log.locker.Lock()
+	defer log.locker.Unlock()
+
+	timestamp := func(i int) time.Time {
+		return log.entries.at(i).value.Timestamp
+	}
+	n := log.entries.len()
+
+	var slice []TimestampedEntry[T]
+	if log.timeOf == nil || log.outOfOrder {
+		log.stats.LinearScans++
+		for i := 0; i < n; i++ {
+			ts := timestamp(i)
+			if (from == nil || from(ts)) && (to == nil || to(ts)) {
+				slice = append(slice, log.entries.at(i).value)
+			}
+		}
+		return slice
+	}
+
+	log.stats.BinarySearches++
+	first, last := 0, n
+	if from != nil {
+		first = sort.Search(n, func(i int) bool {
+			return from(timestamp(i))
+		})
+	}
+	if to != nil {
+		last = sort.Search(n, func(i int) bool {
+			return !to(timestamp(i))
+		})
+	}
+
+	if last > first {
+		slice = make([]TimestampedEntry[T], last-first)
+		for i := range slice {
+			slice[i] = log.entries.at(first + i).value
+		}
+	}
+	return slice
+}
+
+// AgeHistogram counts the entries in log by age, measured from
+// the log's clock.  buckets are upper bounds in increasing order;
+// an entry with age a is counted in the first bucket i for which
+// a < buckets[i], so each bucket includes its lower edge and
+// excludes its upper edge.  The returned slice has one more
+// element than buckets, counting entries at least as old as the
+// last bound.  Entries timestamped in the future have an age
+// of 0.  AgeHistogram panics if buckets are not in strictly
+// increasing order.
+func AgeHistogram[T any](log *MemLog[TimestampedEntry[T]], buckets []time.Duration) []int {
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic("memlog: AgeHistogram buckets must be in increasing order")
+		}
+	}
+
+	log.rlock()
+	defer log.runlock()
+
+	counts := make([]int, len(buckets)+1)
+	now := log.now()
+	log.forEachN(allElements, func(entry TimestampedEntry[T]) {
+		age := max(now.Sub(entry.Timestamp), 0)
+		i := sort.Search(len(buckets), func(i int) bool {
+			return age < buckets[i]
+		})
+		counts[i]++
+	})
+
+	return counts
+}
+
+// OldestAge returns the age of the oldest entry in log,
+// measured from the log's clock, or false if the log is
+// empty.  The entry with the earliest timestamp is used even
+// if entries were appended out of order.  Entries timestamped
+// in the future have an age of 0.
+func OldestAge[T any](log *MemLog[TimestampedEntry[T]]) (age time.Duration, ok bool) {
+	log.rlock()
+	defer log.runlock()
+
+	if log.entries.len() == 0 {
+		return 0, false
+	}
+
+	oldest := log.entries.at(0).value.Timestamp
+	log.forEachN(allElements, func(entry TimestampedEntry[T]) {
+		if entry.Timestamp.Before(oldest) {
+			oldest = entry.Timestamp
+		}
+	})
+
+	return max(log.now().Sub(oldest), 0), true
+}
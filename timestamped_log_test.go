@@ -0,0 +1,333 @@
+package memlog
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_timestamped_log_records_time(t *testing.T) {
+	// given a timestamped log with a fake clock
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[string](10, WithClock[TimestampedEntry[string]](clock))
+
+	// when entries are appended over time
+	AppendTimestamped(log, "item #1")
+	clock.Advance(time.Second)
+	AppendTimestamped(log, "item #2")
+
+	// then each entry has the time it was appended
+	slice := log.Slice()
+	assert.Equal(t, TimestampedEntry[string]{Timestamp: start, Value: "item #1"}, slice[0])
+	assert.Equal(t, TimestampedEntry[string]{Timestamp: start.Add(time.Second), Value: "item #2"}, slice[1])
+}
+
+func Test_timestamped_log_slice_since_time(t *testing.T) {
+	// given a timestamped log with entries one minute apart
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[int](10, WithClock[TimestampedEntry[int]](clock))
+
+	for i := 0; i < 5; i++ {
+		AppendTimestamped(log, i)
+		clock.Advance(time.Minute)
+	}
+
+	// when entries since the third minute are requested
+	slice := SliceSinceTime(log, start.Add(2*time.Minute))
+
+	// then only entries at or after that time are returned
+	assert.Len(t, slice, 3)
+	assert.Equal(t, 2, slice[0].Value)
+	assert.Equal(t, 4, slice[2].Value)
+
+	// and a time after the newest entry returns nothing
+	assert.Empty(t, SliceSinceTime(log, start.Add(time.Hour)))
+}
+
+func Test_timestamped_log_slice_since_and_before(t *testing.T) {
+	// given a timestamped log with entries one minute apart
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[int](10, WithClock[TimestampedEntry[int]](clock))
+
+	for i := 0; i < 5; i++ {
+		AppendTimestamped(log, i)
+		clock.Advance(time.Minute)
+	}
+
+	values := func(entries []TimestampedEntry[int]) []int {
+		var v []int
+		for _, e := range entries {
+			v = append(v, e.Value)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		pivot  time.Time
+		since  []int
+		before []int
+	}{
+		{"before start", start.Add(-time.Second), []int{0, 1, 2, 3, 4}, nil},
+		{"at start", start, []int{0, 1, 2, 3, 4}, nil},
+		{"middle", start.Add(2 * time.Minute), []int{2, 3, 4}, []int{0, 1}},
+		{"between entries", start.Add(90 * time.Second), []int{2, 3, 4}, []int{0, 1}},
+		{"at end", start.Add(4 * time.Minute), []int{4}, []int{0, 1, 2, 3}},
+		{"after end", start.Add(time.Hour), nil, []int{0, 1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.since, values(SliceSince(log, tt.pivot)))
+			assert.Equal(t, tt.before, values(SliceBefore(log, tt.pivot)))
+		})
+	}
+}
+
+func Test_timestamped_log_slice_between_duplicate_timestamps(t *testing.T) {
+	// given entries with duplicate timestamps at the boundaries
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[int](10, WithClock[TimestampedEntry[int]](clock))
+
+	for i := 0; i < 9; i++ {
+		AppendTimestamped(log, i)
+		if i%3 == 2 {
+			clock.Advance(time.Minute)
+		}
+	}
+
+	// when the middle minute is requested
+	slice := SliceBetween(log, start.Add(time.Minute), start.Add(2*time.Minute))
+
+	// then every entry with a boundary timestamp is included once
+	var values []int
+	for _, e := range slice {
+		values = append(values, e.Value)
+	}
+	assert.Equal(t, []int{3, 4, 5}, values)
+	assert.Len(t, SliceSince(log, start.Add(time.Minute)), 6)
+	assert.Len(t, SliceBefore(log, start.Add(time.Minute)), 3)
+
+	stats := log.Stats()
+	assert.False(t, stats.OutOfOrder)
+	assert.Equal(t, uint64(3), stats.BinarySearches)
+	assert.Zero(t, stats.LinearScans)
+}
+
+func Test_timestamped_log_out_of_order(t *testing.T) {
+	// given a timestamped log with an entry appended out of order
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	log := NewTimestampedLog[string](10)
+	log.Append(TimestampedEntry[string]{Timestamp: start, Value: "a"})
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(2 * time.Minute), Value: "c"})
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(time.Minute), Value: "b"})
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(3 * time.Minute), Value: "d"})
+
+	// when entries since a time are requested
+	slice := SliceSince(log, start.Add(time.Minute))
+
+	// then the log is scanned and all matching entries are returned
+	var values []string
+	for _, e := range slice {
+		values = append(values, e.Value)
+	}
+	assert.Equal(t, []string{"c", "b", "d"}, values)
+
+	stats := log.Stats()
+	assert.True(t, stats.OutOfOrder)
+	assert.Equal(t, uint64(1), stats.LinearScans)
+
+	// and clearing the log restores binary searches
+	log.Clear()
+	assert.False(t, log.Stats().OutOfOrder)
+}
+
+func Test_timestamped_entries_in_plain_memlog_out_of_order(t *testing.T) {
+	// given a plain MemLog of timestamped entries appended out of order
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	log := NewMemLog[TimestampedEntry[int]](10)
+	for _, offset := range []int{5, 1, 6, 2} {
+		log.Append(TimestampedEntry[int]{
+			Timestamp: start.Add(time.Duration(offset) * time.Second),
+			Value:     offset,
+		})
+	}
+
+	// when entries are requested by time
+	since := SliceSince(log, start.Add(4*time.Second))
+	before := SliceBefore(log, start.Add(4*time.Second))
+
+	// then the log is scanned and every matching entry is returned
+	var values []int
+	for _, e := range since {
+		values = append(values, e.Value)
+	}
+	assert.Equal(t, []int{5, 6}, values)
+
+	values = nil
+	for _, e := range before {
+		values = append(values, e.Value)
+	}
+	assert.Equal(t, []int{1, 2}, values)
+	assert.Equal(t, uint64(2), log.Stats().LinearScans)
+}
+
+// newBenchmarkTimestampedLog returns a 100k entry timestamped
+// log with entries one second apart.
+func newBenchmarkTimestampedLog(start time.Time) *MemLog[TimestampedEntry[int]] {
+	log := NewTimestampedLog[int](100000)
+	for i := 0; i < 100000; i++ {
+		log.Append(TimestampedEntry[int]{Timestamp: start.Add(time.Duration(i) * time.Second), Value: i})
+	}
+	return log
+}
+
+func Benchmark_timestamped_log_slice_since(b *testing.B) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	log := newBenchmarkTimestampedLog(start)
+	since := start.Add(99900 * time.Second)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = SliceSince(log, since)
+	}
+}
+
+func Benchmark_timestamped_log_slice_since_out_of_order(b *testing.B) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	log := newBenchmarkTimestampedLog(start)
+	log.Append(TimestampedEntry[int]{Timestamp: start, Value: -1})
+	since := start.Add(99900 * time.Second)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = SliceSince(log, since)
+	}
+}
+
+func Test_timestamped_log_age_histogram(t *testing.T) {
+	// given entries whose ages fall on and around bucket edges
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[string](10, WithClock[TimestampedEntry[string]](clock))
+	for _, age := range []time.Duration{
+		30 * time.Minute,
+		5 * time.Minute,
+		5*time.Minute - time.Second,
+		time.Minute,
+		time.Minute - time.Nanosecond,
+		0,
+	} {
+		log.Append(TimestampedEntry[string]{Timestamp: start.Add(-age)})
+	}
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(time.Hour)})
+
+	// when the histogram is computed
+	counts := AgeHistogram(log, []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute})
+
+	// then each bucket includes its lower edge and excludes its upper edge,
+	// entries in the future count as age 0 and the last count is overflow
+	assert.Equal(t, []int{3, 2, 1, 1}, counts)
+
+	// and ages are measured from the log's clock
+	clock.Advance(time.Hour)
+	counts = AgeHistogram(log, []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute})
+	assert.Equal(t, []int{1, 0, 0, 6}, counts)
+}
+
+func Test_timestamped_log_age_histogram_empty(t *testing.T) {
+	log := NewTimestampedLog[string](10)
+
+	assert.Equal(t, []int{0, 0, 0}, AgeHistogram(log, []time.Duration{time.Second, time.Minute}))
+	assert.Equal(t, []int{0}, AgeHistogram(log, nil))
+
+	log.Append(TimestampedEntry[string]{Timestamp: time.Now()})
+	assert.Equal(t, []int{1}, AgeHistogram(log, nil))
+}
+
+func Test_timestamped_log_oldest_age(t *testing.T) {
+	// given a log with an entry appended out of order
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[string](10, WithClock[TimestampedEntry[string]](clock))
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(-time.Minute)})
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(-time.Hour)})
+	log.Append(TimestampedEntry[string]{Timestamp: start})
+
+	// then the earliest timestamp is used
+	age, ok := OldestAge(log)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, age)
+
+	clock.Advance(time.Minute)
+	age, _ = OldestAge(log)
+	assert.Equal(t, time.Hour+time.Minute, age)
+}
+
+func Test_timestamped_log_oldest_age_edges(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	log := NewTimestampedLog[string](10, WithClock[TimestampedEntry[string]](clock))
+
+	// an empty log has no oldest entry
+	age, ok := OldestAge(log)
+	assert.False(t, ok)
+	assert.Zero(t, age)
+
+	// an entry in the future has an age of 0
+	log.Append(TimestampedEntry[string]{Timestamp: start.Add(time.Minute)})
+	age, ok = OldestAge(log)
+	assert.True(t, ok)
+	assert.Zero(t, age)
+}
+
+func Test_timestamped_log_concurrent_appends_in_order(t *testing.T) {
+	// given a timestamped log with a clock that advances on every read
+	var ticks atomic.Int64
+	clock := ClockFunc(func() time.Time {
+		return time.Unix(0, ticks.Add(1))
+	})
+	log := NewTimestampedLog[int](10000, WithClock[TimestampedEntry[int]](clock))
+
+	// when many goroutines append at once
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				AppendTimestamped(log, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then the entries are stored in timestamp order
+	assert.False(t, log.Stats().OutOfOrder)
+	entries := log.Slice()
+	for i := 1; i < len(entries); i++ {
+		assert.True(t, entries[i-1].Timestamp.Before(entries[i].Timestamp))
+	}
+}
+
+func Test_age_histogram_requires_increasing_buckets(t *testing.T) {
+	// given a timestamped log
+	log := NewTimestampedLog[int](10)
+	AppendTimestamped(log, 1)
+
+	// when buckets are out of order or repeated
+	// then AgeHistogram panics
+	assert.Panics(t, func() {
+		AgeHistogram(log, []time.Duration{time.Minute, time.Second})
+	})
+	assert.Panics(t, func() {
+		AgeHistogram(log, []time.Duration{time.Second, time.Second})
+	})
+}